@@ -1,6 +1,11 @@
 package alias
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
 	"github.com/xwjdsh/lunar"
 	"github.com/xwjdsh/lunar/config"
 )
@@ -9,11 +14,63 @@ import (
 type Alias struct {
 	Name  string
 	Dates []lunar.DateType
-	Tags  []string
+	// Rule the alias recurs by the rule instead of Dates if it is not nil
+	Rule *Rule
+	// Anchor the alias recurs by the solar term instead of Dates if it is not nil
+	Anchor *Anchor
+	Tags   []string
+}
+
+// Rule the nth weekday of a gregorian month, see lunar.NthWeekday
+type Rule struct {
+	Month   int
+	Weekday time.Weekday
+	Nth     int
+}
+
+// Date returns the date of the rule in the year, false if the month has no such day
+func (r Rule) Date(year int) (lunar.Date, bool) {
+	return lunar.NthWeekday(year, r.Month, r.Weekday, r.Nth)
+}
+
+// Anchor the day offset days from a solar term, eg. 寒食 is one day before 清明
+type Anchor struct {
+	SolarTerm  string
+	OffsetDays int
+}
+
+// Date returns the date of the anchor by the date of the solar term
+func (a Anchor) Date(term lunar.Date) lunar.Date {
+	return lunar.DateByTime(term.Time().AddDate(0, 0, a.OffsetDays))
+}
+
+// match reports whether the date is the date of the anchor
+func (a Anchor) match(h *lunar.Handler, d lunar.Date) bool {
+	t, err := lunar.ParseSolarTerm(a.SolarTerm)
+	if err != nil {
+		return false
+	}
+	r, err := h.Calendar(lunar.DateByTime(d.Time().AddDate(0, 0, -a.OffsetDays)))
+
+	return err == nil && r.Term == t
 }
 
 // ConvertAlias convert config.Alias to Alias
 func ConvertAlias(c *config.Alias) *Alias {
+	if c.Rule != nil {
+		return NewRule(c.Name, Rule{
+			Month:   c.Rule.Month,
+			Weekday: time.Weekday(c.Rule.Weekday),
+			Nth:     c.Rule.Nth,
+		}, c.Tags...)
+	}
+	if c.Anchor != nil {
+		return NewAnchor(c.Name, Anchor{
+			SolarTerm:  c.Anchor.SolarTerm,
+			OffsetDays: c.Anchor.OffsetDays,
+		}, c.Tags...)
+	}
+
 	var dts []lunar.DateType
 	date := lunar.Date(c.Date)
 	if c.IsLunarDate {
@@ -34,6 +91,24 @@ func New(name string, ds []lunar.DateType, tags ...string) *Alias {
 	}
 }
 
+// NewRule returns a new Alias recurring by the rule
+func NewRule(name string, rule Rule, tags ...string) *Alias {
+	return &Alias{
+		Name: name,
+		Rule: &rule,
+		Tags: tags,
+	}
+}
+
+// NewAnchor returns a new Alias recurring by the solar term
+func NewAnchor(name string, anchor Anchor, tags ...string) *Alias {
+	return &Alias{
+		Name:   name,
+		Anchor: &anchor,
+		Tags:   tags,
+	}
+}
+
 // Result wraps lunar.Result with aliases
 type Result struct {
 	Aliases []Alias
@@ -45,6 +120,10 @@ type Handler struct {
 	*lunar.Handler
 	aliasMap       map[string]*Alias
 	dateToAliasMap map[lunar.DateType][]*Alias
+	ruleAliases    []*Alias
+	anchorAliases  []*Alias
+	// hasNegativeDays whether any lunar date alias counts days from the end of the month
+	hasNegativeDays bool
 }
 
 // NewHandler returns a new Handler
@@ -115,6 +194,26 @@ func (h *Handler) getAliases(year int, filterFunc func(*Alias) bool) ([]*Result,
 
 func (h *Handler) getAliasResult(a *Alias, year int) ([]*Result, error) {
 	results := []*Result{}
+	if a.Rule != nil {
+		d, ok := a.Rule.Date(year)
+		if !ok {
+			return results, nil
+		}
+		r, err := h.WrapResult(h.Calendar(d))
+		if err != nil {
+			if err == lunar.ErrNotFound || errors.Is(err, fs.ErrNotExist) {
+				return results, nil
+			}
+			return nil, err
+		}
+
+		return append(results, r), nil
+	}
+
+	if a.Anchor != nil {
+		return h.getAnchorResults(a.Anchor, year)
+	}
+
 	for _, dt := range a.Dates {
 		if !dt.IsLunarDate() {
 			d := dt.(lunar.Date)
@@ -133,9 +232,10 @@ func (h *Handler) getAliasResult(a *Alias, year int) ([]*Result, error) {
 		d := dt.(lunar.LunarDate)
 		for _, y := range []int{year, year - 1} {
 			d.Year = y
-			r, err := h.Calendar(d)
+			r, err := h.calendarLunarDate(d)
 			if err != nil {
-				if err == lunar.ErrNotFound {
+				// the date may be out of the source, eg. 腊月 of 2100 is in 2101
+				if err == lunar.ErrNotFound || errors.Is(err, fs.ErrNotExist) {
 					continue
 				}
 				return nil, err
@@ -149,6 +249,80 @@ func (h *Handler) getAliasResult(a *Alias, year int) ([]*Result, error) {
 	return results, nil
 }
 
+// calendarLunarDate is Calendar with negative days counted from the end of the month,
+// eg. day -1 of 腊月 is 除夕, the 29th or the 30th
+func (h *Handler) calendarLunarDate(d lunar.LunarDate) (*lunar.Result, error) {
+	if d.Day < 0 {
+		days, err := h.LunarMonthDays(d.Year, d.Month, d.IsLeapMonth)
+		if err != nil {
+			return nil, err
+		}
+		d.Day += days + 1
+		if d.Day < 1 {
+			return nil, lunar.ErrNotFound
+		}
+	}
+
+	return h.Calendar(d)
+}
+
+// getAnchorResults returns results of the anchor in the gregorian year,
+// the solar terms of the years around are searched since the offset may cross years
+func (h *Handler) getAnchorResults(a *Anchor, year int) ([]*Result, error) {
+	t, err := lunar.ParseSolarTerm(a.SolarTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*Result{}
+	for _, y := range []int{year - 1, year, year + 1} {
+		term, err := h.solarTermDate(t, y)
+		if err != nil {
+			if err == lunar.ErrNotFound || errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		d := a.Date(term)
+		if d.Year != year {
+			continue
+		}
+		r, err := h.WrapResult(h.Calendar(d))
+		if err != nil {
+			if err == lunar.ErrNotFound || errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		results = append(results, r)
+	}
+
+	return results, nil
+}
+
+// solarTermDate returns the date of the solar term in the gregorian year by walking its days,
+// unlike GetSolarTerms it does not need the next year, eg. the last year of the table
+func (h *Handler) solarTermDate(t lunar.SolarTerm, year int) (lunar.Date, error) {
+	var (
+		d     lunar.Date
+		found bool
+	)
+	err := h.RangeFunc(lunar.NewDate(year, 1, 1), lunar.NewDate(year, 12, 31), func(r *lunar.Result) bool {
+		if r.Term == t {
+			d, found = r.Date, true
+		}
+		return !found
+	})
+	if err != nil {
+		return lunar.Date{}, err
+	}
+	if !found {
+		return lunar.Date{}, lunar.ErrNotFound
+	}
+
+	return d, nil
+}
+
 // WrapResults wrap results with alias info
 func (h *Handler) WrapResults(rs []*lunar.Result, err error) ([]*Result, error) {
 	if err != nil {
@@ -190,24 +364,79 @@ func (h *Handler) resultWithAliases(r *lunar.Result) *Result {
 		}
 	}
 
+	if h.hasNegativeDays {
+		if days, err := h.LunarMonthDays(r.LunarDate.Year, d1.Month, d1.IsLeapMonth); err == nil {
+			d1.Day -= days + 1
+			for _, a := range h.dateToAliasMap[d1] {
+				nr.Aliases = append(nr.Aliases, *a)
+			}
+		}
+	}
+
+	for _, a := range h.ruleAliases {
+		if d, ok := a.Rule.Date(r.Date.Year); ok && d == r.Date {
+			nr.Aliases = append(nr.Aliases, *a)
+		}
+	}
+
+	for _, a := range h.anchorAliases {
+		if a.Anchor.match(h.Handler, r.Date) {
+			nr.Aliases = append(nr.Aliases, *a)
+		}
+	}
+
 	return nr
 }
 
-// LoadAlias load alias config
-func (h *Handler) LoadAlias(cs []*config.Alias) {
-	h.aliasMap = map[string]*Alias{}
+// LoadAlias load alias config, anchors are validated here since the config package can not parse
+// solar terms, the loaded aliases are kept if any of the config is invalid
+func (h *Handler) LoadAlias(cs []*config.Alias) error {
+	aliasMap := map[string]*Alias{}
 	for _, c := range cs {
-		if !c.Disable {
-			h.aliasMap[c.Name] = ConvertAlias(c)
+		if c.Disable {
+			continue
+		}
+		if err := validateAnchor(c.Anchor); err != nil {
+			return fmt.Errorf("alias %s: %w", c.Name, err)
 		}
+		aliasMap[c.Name] = ConvertAlias(c)
 	}
 
+	h.aliasMap = aliasMap
+
 	h.dateToAliasMap = map[lunar.DateType][]*Alias{}
+	h.ruleAliases, h.anchorAliases, h.hasNegativeDays = nil, nil, false
 	for _, a := range h.aliasMap {
+		if a.Rule != nil {
+			h.ruleAliases = append(h.ruleAliases, a)
+			continue
+		}
+		if a.Anchor != nil {
+			h.anchorAliases = append(h.anchorAliases, a)
+			continue
+		}
 		for _, dt := range a.Dates {
+			if d, ok := dt.(lunar.LunarDate); ok && d.Day < 0 {
+				h.hasNegativeDays = true
+			}
 			h.dateToAliasMap[dt] = append(h.dateToAliasMap[dt], a)
 		}
 	}
+
+	return nil
+}
+
+// validateAnchor checks the solar term of the anchor config if any
+func validateAnchor(a *config.Anchor) error {
+	if a == nil {
+		return nil
+	}
+	if a.SolarTerm == "" {
+		return errors.New("solar term of the anchor is required")
+	}
+	_, err := lunar.ParseSolarTerm(a.SolarTerm)
+
+	return err
 }
 
 func getLunarDates(d lunar.Date, leapMonthType config.LeapMonthLimitType) []lunar.DateType {
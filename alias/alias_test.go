@@ -0,0 +1,133 @@
+package alias
+
+import (
+	"testing"
+
+	"github.com/xwjdsh/lunar"
+	"github.com/xwjdsh/lunar/config"
+)
+
+// newTestHandler returns a Handler with the default aliases and the aliases
+func newTestHandler(t *testing.T, as ...*config.Alias) *Handler {
+	conf, err := config.Init("", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHandler(lunar.New())
+	if err := h.LoadAlias(append(conf.Aliases, as...)); err != nil {
+		t.Fatal(err)
+	}
+
+	return h
+}
+
+// testAliasDates checks the dates of the alias in the year, and the alias of the results of the dates
+func testAliasDates(t *testing.T, h *Handler, name string, year int, expected ...lunar.Date) {
+	rs, err := h.GetAliases(year, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs) != len(expected) {
+		t.Fatalf("GetAliases error, alias: %s, year: %d, expected: %v, actual: %d results", name, year, expected, len(rs))
+	}
+	for i, r := range rs {
+		if r.Date != expected[i] {
+			t.Errorf("GetAliases error, alias: %s, year: %d, expected: %s, actual: %s", name, year, expected[i], r.Date)
+		}
+	}
+
+	for _, d := range expected {
+		r, err := h.WrapResult(h.Calendar(d))
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, a := range r.Aliases {
+			found = found || a.Name == name
+		}
+		if !found {
+			t.Errorf("WrapResult error, date: %s, expected alias: %s, actual: %v", d, name, r.Aliases)
+		}
+	}
+}
+
+func TestAnchorAlias(t *testing.T) {
+	h := newTestHandler(t,
+		config.NewAnchorAlias("寒食", config.Anchor{SolarTerm: "清明", OffsetDays: -1}),
+		config.NewAnchorAlias("小寒前十日", config.Anchor{SolarTerm: "xiaohan", OffsetDays: -10}),
+		config.NewAnchorAlias("冬至后七十二日", config.Anchor{SolarTerm: "Winter Solstice", OffsetDays: 72}),
+	)
+	for _, c := range []struct {
+		name     string
+		year     int
+		expected lunar.Date
+	}{
+		{"清明", 2015, lunar.NewDate(2015, 4, 5)},
+		{"清明", 2024, lunar.NewDate(2024, 4, 4)},
+		{"寒食", 2015, lunar.NewDate(2015, 4, 4)},
+		{"寒食", 2024, lunar.NewDate(2024, 4, 3)},
+		// 小寒 of 2024 is 2024-01-06, its offset date is in 2023
+		{"小寒前十日", 2023, lunar.NewDate(2023, 12, 27)},
+		{"小寒前十日", 2024, lunar.NewDate(2024, 12, 26)},
+		// 冬至 of 2023 is 2023-12-22
+		{"冬至后七十二日", 2024, lunar.NewDate(2024, 3, 3)},
+		// the first and the last years of the table
+		{"清明", 1901, lunar.NewDate(1901, 4, 5)},
+		{"清明", 2100, lunar.NewDate(2100, 4, 5)},
+	} {
+		testAliasDates(t, h, c.name, c.year, c.expected)
+	}
+}
+
+func TestLoadAlias(t *testing.T) {
+	h := newTestHandler(t)
+	for _, anchor := range []config.Anchor{
+		{SolarTerm: "qingmin", OffsetDays: -1},
+		{OffsetDays: -1},
+	} {
+		if err := h.LoadAlias([]*config.Alias{config.NewAnchorAlias("寒食", anchor)}); err == nil {
+			t.Errorf("LoadAlias error, anchor: %+v, expected: invalid solar term error, actual: nil", anchor)
+		}
+	}
+
+	// the aliases loaded before are kept
+	testAliasDates(t, h, "清明", 2024, lunar.NewDate(2024, 4, 4))
+}
+
+func TestNegativeDays(t *testing.T) {
+	h := newTestHandler(t)
+	for _, c := range []struct {
+		year     int
+		expected []lunar.Date
+	}{
+		// 腊月 of 2024 has 29 days
+		{2025, []lunar.Date{lunar.NewDate(2025, 1, 28)}},
+		// 腊月 of 2023 has 30 days
+		{2024, []lunar.Date{lunar.NewDate(2024, 2, 9)}},
+		// 除夕 of lunar 2100 is in 2101, out of the table
+		{2100, []lunar.Date{lunar.NewDate(2100, 2, 8)}},
+	} {
+		testAliasDates(t, h, "除夕", c.year, c.expected...)
+	}
+
+	if _, err := h.calendarLunarDate(lunar.NewLunarDate(lunar.NewDate(2100, 12, -1), false)); err == nil {
+		t.Error("calendarLunarDate error, expected: out of the table error, actual: nil")
+	}
+}
+
+func TestRuleAlias(t *testing.T) {
+	h := newTestHandler(t)
+	for _, c := range []struct {
+		name     string
+		year     int
+		expected lunar.Date
+	}{
+		{"母亲节", 2024, lunar.NewDate(2024, 5, 12)},
+		{"母亲节", 2025, lunar.NewDate(2025, 5, 11)},
+		{"父亲节", 2024, lunar.NewDate(2024, 6, 16)},
+		{"父亲节", 2025, lunar.NewDate(2025, 6, 15)},
+	} {
+		testAliasDates(t, h, c.name, c.year, c.expected)
+	}
+}
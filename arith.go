@@ -0,0 +1,179 @@
+package lunar
+
+// LeapMonthPolicy decides how AddMonths and AddYears treat leap months
+type LeapMonthPolicy int
+
+const (
+	// LeapMonthAsMonth counts a leap month as a month of its own,
+	// eg. 三月 + 2 months is 闰四月 in 2020, AddYears keeps a leap month if the target year has it
+	LeapMonthAsMonth LeapMonthPolicy = iota
+	// LeapMonthSkip does not count leap months, a leap month counts from its regular month,
+	// eg. 三月 + 2 months is 五月 in 2020, AddYears always returns regular months
+	LeapMonthSkip
+)
+
+// DayOverflowPolicy decides the day 30 in a month of 29 days
+type DayOverflowPolicy int
+
+const (
+	// DayOverflowClamp uses the last day of the month
+	DayOverflowClamp DayOverflowPolicy = iota
+	// DayOverflowNextMonth moves the overflowed days to the next month, eg. 三十 is 初一 of the next month
+	DayOverflowNextMonth
+	// DayOverflowError returns ErrNotFound
+	DayOverflowError
+)
+
+// MonthPolicy policies of AddMonths and AddYears, the zero value counts leap months and clamps days
+type MonthPolicy struct {
+	LeapMonth   LeapMonthPolicy
+	DayOverflow DayOverflowPolicy
+}
+
+// AddDays returns the date days after the date, days can be negative
+func AddDays(dt DateType, days int) (*Result, error) {
+	return defaultHandler.AddDays(dt, days)
+}
+
+// AddDays returns the date days after the date, days can be negative
+func (h *Handler) AddDays(dt DateType, days int) (*Result, error) {
+	r, err := h.Calendar(dt)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.Calendar(DateByTime(r.Date.Time().AddDate(0, 0, days)))
+}
+
+// DaysBetween returns days from the date from to the date to, negative if to is before from
+func DaysBetween(from, to DateType) (int, error) {
+	return defaultHandler.DaysBetween(from, to)
+}
+
+// DaysBetween returns days from the date from to the date to, negative if to is before from
+func (h *Handler) DaysBetween(from, to DateType) (int, error) {
+	start, err := h.Calendar(from)
+	if err != nil {
+		return 0, err
+	}
+	end, err := h.Calendar(to)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(end.Date.Time().Sub(start.Date.Time()).Hours() / 24), nil
+}
+
+// AddMonths returns the lunar date months after the date, months can be negative
+func AddMonths(d LunarDate, months int, policy MonthPolicy) (*Result, error) {
+	return defaultHandler.AddMonths(d, months, policy)
+}
+
+// AddMonths returns the lunar date months after the date, months can be negative
+func (h *Handler) AddMonths(d LunarDate, months int, policy MonthPolicy) (*Result, error) {
+	if _, err := h.Calendar(d); err != nil {
+		return nil, err
+	}
+
+	year := d.Year
+	list, err := h.lunarMonths(year, policy.LeapMonth)
+	if err != nil {
+		return nil, err
+	}
+	i := 0
+	for i < len(list) && !(list[i].Month == d.Month && list[i].IsLeapMonth == d.IsLeapMonth) {
+		i++
+	}
+	if i == len(list) {
+		// a leap month skipped by the policy
+		i = d.Month - 1
+	}
+
+	for i += months; i >= len(list); {
+		i -= len(list)
+		year++
+		if list, err = h.lunarMonths(year, policy.LeapMonth); err != nil {
+			return nil, err
+		}
+	}
+	for i < 0 {
+		year--
+		if list, err = h.lunarMonths(year, policy.LeapMonth); err != nil {
+			return nil, err
+		}
+		i += len(list)
+	}
+
+	return h.monthDay(list[i], d.Day, policy.DayOverflow)
+}
+
+// AddYears returns the lunar date years after the date, years can be negative,
+// a leap month becomes the regular month if the target year has not the leap month
+func AddYears(d LunarDate, years int, policy MonthPolicy) (*Result, error) {
+	return defaultHandler.AddYears(d, years, policy)
+}
+
+// AddYears returns the lunar date years after the date, years can be negative,
+// a leap month becomes the regular month if the target year has not the leap month
+func (h *Handler) AddYears(d LunarDate, years int, policy MonthPolicy) (*Result, error) {
+	if _, err := h.Calendar(d); err != nil {
+		return nil, err
+	}
+
+	month := NewLunarDate(NewDate(d.Year+years, d.Month, 1), false)
+	if d.IsLeapMonth && policy.LeapMonth == LeapMonthAsMonth {
+		leapMonth, err := h.LeapMonth(month.Year)
+		if err != nil {
+			return nil, err
+		}
+		month.IsLeapMonth = leapMonth == d.Month
+	}
+
+	return h.monthDay(month, d.Day, policy.DayOverflow)
+}
+
+// lunarMonths returns the first days of months of the lunar year in order,
+// the leap month follows its regular month unless it is skipped by the policy
+func (h *Handler) lunarMonths(year int, policy LeapMonthPolicy) ([]LunarDate, error) {
+	leapMonth := 0
+	if policy == LeapMonthAsMonth {
+		var err error
+		if leapMonth, err = h.LeapMonth(year); err != nil {
+			return nil, err
+		}
+	}
+
+	months := make([]LunarDate, 0, 13)
+	for month := 1; month <= 12; month++ {
+		months = append(months, NewLunarDate(NewDate(year, month, 1), false))
+		if month == leapMonth {
+			months = append(months, NewLunarDate(NewDate(year, month, 1), true))
+		}
+	}
+
+	return months, nil
+}
+
+// monthDay returns the day of the month, days beyond the month are handled by the policy
+func (h *Handler) monthDay(month LunarDate, day int, policy DayOverflowPolicy) (*Result, error) {
+	days, err := h.LunarMonthDays(month.Year, month.Month, month.IsLeapMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	month.Day = day
+	if day <= days {
+		return h.Calendar(month)
+	}
+
+	switch policy {
+	case DayOverflowNextMonth:
+		month.Day = days
+		return h.AddDays(month, day-days)
+	case DayOverflowError:
+		return nil, ErrNotFound
+	}
+
+	month.Day = days
+	return h.Calendar(month)
+}
@@ -0,0 +1,121 @@
+package lunar
+
+import "testing"
+
+func TestAddDays(t *testing.T) {
+	for _, c := range []struct {
+		dt       DateType
+		days     int
+		expected LunarDate
+	}{
+		{NewDate(2020, 5, 23), 29, NewLunarDate(NewDate(2020, 5, 1), false)},
+		{NewLunarDate(NewDate(2020, 4, 30), false), 1, NewLunarDate(NewDate(2020, 4, 1), true)},
+		{NewLunarDate(NewDate(2020, 5, 1), false), -1, NewLunarDate(NewDate(2020, 4, 29), true)},
+		{NewLunarDate(NewDate(2023, 2, 1), true), 100, NewLunarDate(NewDate(2023, 5, 13), false)},
+		{NewLunarDate(NewDate(2023, 12, 30), false), 1, NewLunarDate(NewDate(2024, 1, 1), false)},
+	} {
+		r, err := AddDays(c.dt, c.days)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.LunarDate != c.expected {
+			t.Errorf("AddDays error, date: %v %+d, expected: %v, actual: %v", c.dt, c.days, c.expected, r.LunarDate)
+		}
+	}
+
+	if _, err := AddDays(NewLunarDate(NewDate(2023, 1, 30), false), 1); err != ErrNotFound {
+		t.Errorf("AddDays error, expected: %v, actual: %v", ErrNotFound, err)
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	for _, c := range []struct {
+		from, to DateType
+		expected int
+	}{
+		{NewLunarDate(NewDate(2020, 1, 1), false), NewLunarDate(NewDate(2021, 1, 1), false), 384},
+		{NewLunarDate(NewDate(2023, 2, 1), true), NewLunarDate(NewDate(2023, 3, 1), false), 29},
+		{NewLunarDate(NewDate(2023, 3, 1), false), NewLunarDate(NewDate(2023, 2, 1), true), -29},
+		{NewDate(2020, 5, 23), NewLunarDate(NewDate(2020, 4, 1), true), 0},
+		{NewDate(2024, 1, 1), NewDate(2025, 1, 1), 366},
+	} {
+		actual, err := DaysBetween(c.from, c.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != c.expected {
+			t.Errorf("DaysBetween error, %v to %v, expected: %d, actual: %d", c.from, c.to, c.expected, actual)
+		}
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	skip := MonthPolicy{LeapMonth: LeapMonthSkip}
+	for _, c := range []struct {
+		date     LunarDate
+		months   int
+		policy   MonthPolicy
+		expected LunarDate
+		err      error
+	}{
+		{NewLunarDate(NewDate(2020, 3, 30), false), 1, MonthPolicy{}, NewLunarDate(NewDate(2020, 4, 30), false), nil},
+		// 闰四月 of 2020 has 29 days
+		{NewLunarDate(NewDate(2020, 3, 30), false), 2, MonthPolicy{}, NewLunarDate(NewDate(2020, 4, 29), true), nil},
+		{NewLunarDate(NewDate(2020, 3, 30), false), 2, MonthPolicy{DayOverflow: DayOverflowNextMonth}, NewLunarDate(NewDate(2020, 5, 1), false), nil},
+		{NewLunarDate(NewDate(2020, 3, 30), false), 2, MonthPolicy{DayOverflow: DayOverflowError}, LunarDate{}, ErrNotFound},
+		{NewLunarDate(NewDate(2020, 3, 15), false), 2, skip, NewLunarDate(NewDate(2020, 5, 15), false), nil},
+		{NewLunarDate(NewDate(2020, 4, 10), false), 1, MonthPolicy{}, NewLunarDate(NewDate(2020, 4, 10), true), nil},
+		{NewLunarDate(NewDate(2020, 4, 10), false), 1, skip, NewLunarDate(NewDate(2020, 5, 10), false), nil},
+		{NewLunarDate(NewDate(2020, 4, 10), true), 1, MonthPolicy{}, NewLunarDate(NewDate(2020, 5, 10), false), nil},
+		{NewLunarDate(NewDate(2020, 4, 10), true), 1, skip, NewLunarDate(NewDate(2020, 5, 10), false), nil},
+		{NewLunarDate(NewDate(2020, 5, 1), false), -1, MonthPolicy{}, NewLunarDate(NewDate(2020, 4, 1), true), nil},
+		{NewLunarDate(NewDate(2020, 5, 1), false), -1, skip, NewLunarDate(NewDate(2020, 4, 1), false), nil},
+		{NewLunarDate(NewDate(2020, 12, 1), false), 2, MonthPolicy{}, NewLunarDate(NewDate(2021, 2, 1), false), nil},
+		// 闰二月 of 2023 has 29 days
+		{NewLunarDate(NewDate(2023, 2, 30), false), 1, MonthPolicy{}, NewLunarDate(NewDate(2023, 2, 29), true), nil},
+		{NewLunarDate(NewDate(2023, 1, 1), false), 3, MonthPolicy{}, NewLunarDate(NewDate(2023, 3, 1), false), nil},
+		{NewLunarDate(NewDate(2023, 1, 1), false), 3, skip, NewLunarDate(NewDate(2023, 4, 1), false), nil},
+		{NewLunarDate(NewDate(2023, 3, 1), false), -13, MonthPolicy{}, NewLunarDate(NewDate(2022, 3, 1), false), nil},
+		{NewLunarDate(NewDate(2023, 3, 1), false), -12, skip, NewLunarDate(NewDate(2022, 3, 1), false), nil},
+	} {
+		r, err := AddMonths(c.date, c.months, c.policy)
+		if err != c.err {
+			t.Errorf("AddMonths error, date: %v %+d, expected: %v, actual: %v", c.date, c.months, c.err, err)
+			continue
+		}
+		if err == nil && r.LunarDate != c.expected {
+			t.Errorf("AddMonths error, date: %v %+d, expected: %v, actual: %v", c.date, c.months, c.expected, r.LunarDate)
+		}
+	}
+}
+
+func TestAddYears(t *testing.T) {
+	for _, c := range []struct {
+		date     LunarDate
+		years    int
+		policy   MonthPolicy
+		expected LunarDate
+		err      error
+	}{
+		{NewLunarDate(NewDate(2020, 1, 1), false), 3, MonthPolicy{}, NewLunarDate(NewDate(2023, 1, 1), false), nil},
+		// 四月 of 2021 has 29 days
+		{NewLunarDate(NewDate(2020, 4, 30), false), 1, MonthPolicy{}, NewLunarDate(NewDate(2021, 4, 29), false), nil},
+		{NewLunarDate(NewDate(2020, 4, 30), false), 1, MonthPolicy{DayOverflow: DayOverflowNextMonth}, NewLunarDate(NewDate(2021, 5, 1), false), nil},
+		{NewLunarDate(NewDate(2020, 4, 30), false), 1, MonthPolicy{DayOverflow: DayOverflowError}, LunarDate{}, ErrNotFound},
+		// 2023 has no 闰四月
+		{NewLunarDate(NewDate(2020, 4, 10), true), 3, MonthPolicy{}, NewLunarDate(NewDate(2023, 4, 10), false), nil},
+		// 2001 has 闰四月 too
+		{NewLunarDate(NewDate(2020, 4, 1), true), -19, MonthPolicy{}, NewLunarDate(NewDate(2001, 4, 1), true), nil},
+		{NewLunarDate(NewDate(2020, 4, 1), true), -19, MonthPolicy{LeapMonth: LeapMonthSkip}, NewLunarDate(NewDate(2001, 4, 1), false), nil},
+		{NewLunarDate(NewDate(2023, 2, 1), true), -3, MonthPolicy{}, NewLunarDate(NewDate(2020, 2, 1), false), nil},
+	} {
+		r, err := AddYears(c.date, c.years, c.policy)
+		if err != c.err {
+			t.Errorf("AddYears error, date: %v %+d, expected: %v, actual: %v", c.date, c.years, c.err, err)
+			continue
+		}
+		if err == nil && r.LunarDate != c.expected {
+			t.Errorf("AddYears error, date: %v %+d, expected: %v, actual: %v", c.date, c.years, c.expected, r.LunarDate)
+		}
+	}
+}
@@ -0,0 +1,515 @@
+package lunar
+
+import (
+	"io"
+	"math"
+	"time"
+)
+
+const (
+	// j2000 julian day of 2000-01-01 12:00 TT
+	j2000 = 2451545.0
+	// unixEpochDay julian day number of 1970-01-01
+	unixEpochDay = 2440588
+	// beijingOffset UTC+8 in days
+	beijingOffset   = 8.0 / 24
+	tropicalYear    = 365.2422
+	synodicMonth    = 29.530588861
+	secondsOfDay    = 86400
+	degreesToRadian = math.Pi / 180
+)
+
+// astronomicalSource computes the calendar following GB/T 33661-2017:
+// days begin at midnight of Beijing time (UTC+8), months begin on the day of the new moon,
+// the month containing 冬至 is the 11th month, and if there are 13 months between two
+// 11th months, the first one without a principal term (中气) is the leap month
+type astronomicalSource struct{}
+
+// NewAstronomicalSource returns a DataSource which computes the calendar by the positions
+// of the sun and the moon, it is not bounded by the embedded tables and is intended for
+// years about 1800~2300
+func NewAstronomicalSource() DataSource {
+	return astronomicalSource{}
+}
+
+func (astronomicalSource) Open(year int) (DayIterator, error) {
+	// months from the 11th month of year-1 to the one before the 11th month of year+1,
+	// which cover the whole year
+	months := append(suiMonths(year), suiMonths(year+1)...)
+
+	terms := map[int]string{}
+	for i, name := range solarTermNames {
+		terms[solarTermDay(year, SolarTerm(i).Longitude())] = name
+	}
+
+	return &astronomicalReader{
+		months: months,
+		terms:  terms,
+		day:    dayNumber(NewDate(year, 1, 1)),
+		end:    dayNumber(NewDate(year+1, 1, 1)),
+	}, nil
+}
+
+type astronomicalReader struct {
+	months []astroMonth
+	terms  map[int]string
+	day    int
+	end    int
+}
+
+func (r *astronomicalReader) Next() (*Day, error) {
+	if r.day >= r.end {
+		return nil, io.EOF
+	}
+
+	for len(r.months) > 1 && r.months[1].start <= r.day {
+		r.months = r.months[1:]
+	}
+	m := r.months[0]
+	d := &Day{
+		Date:        dateOfDayNumber(r.day),
+		LunarMonth:  m.number,
+		IsLeapMonth: m.isLeap,
+		LunarDay:    r.day - m.start + 1,
+		SolarTerm:   r.terms[r.day],
+	}
+	r.day++
+
+	return d, nil
+}
+
+func (r *astronomicalReader) Close() error {
+	return nil
+}
+
+// astroMonth lunar month begins on the day number
+type astroMonth struct {
+	start  int
+	number int
+	isLeap bool
+}
+
+// suiMonths months from the 11th month containing 冬至 of year-1,
+// to the one before the 11th month containing 冬至 of year
+func suiMonths(year int) []astroMonth {
+	w0, w1 := solarTermDay(year-1, 270), solarTermDay(year, 270)
+
+	starts := []int{}
+	for k := newMoonIndex(w0); ; k++ {
+		d := newMoonDay(k)
+		if d > w1 {
+			break
+		}
+		starts = append(starts, d)
+	}
+	// the last one is the next 11th month
+	next := starts[len(starts)-1]
+	starts = starts[:len(starts)-1]
+
+	leap := -1
+	if len(starts) == 13 {
+		principals := []int{w0}
+		for l := 300.0; l <= 600; l += 30 {
+			principals = append(principals, solarTermDay(year, math.Mod(l, 360)))
+		}
+
+		for i, start := range starts {
+			end := next
+			if i+1 < len(starts) {
+				end = starts[i+1]
+			}
+			if !hasDayBetween(principals, start, end) {
+				leap = i
+				break
+			}
+		}
+	}
+
+	months := make([]astroMonth, 0, len(starts))
+	number := 10
+	for i, start := range starts {
+		if i == leap {
+			months = append(months, astroMonth{start: start, number: number, isLeap: true})
+			continue
+		}
+		number = number%12 + 1
+		months = append(months, astroMonth{start: start, number: number})
+	}
+
+	return months
+}
+
+func hasDayBetween(days []int, start, end int) bool {
+	for _, d := range days {
+		if d >= start && d < end {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dayNumber julian day number of the date
+func dayNumber(d Date) int {
+	return int(d.Time().Unix()/secondsOfDay) + unixEpochDay
+}
+
+func dateOfDayNumber(n int) Date {
+	return DateByTime(time.Unix(int64(n-unixEpochDay)*secondsOfDay, 0).UTC())
+}
+
+// beijingDayNumber julian day number of the Beijing date of the moment in TT
+func beijingDayNumber(jde float64) int {
+	return int(math.Floor(jde - deltaT(jde)/secondsOfDay + beijingOffset + 0.5))
+}
+
+// solarTermDay day number of the moment when the apparent longitude of the sun
+// reaches the longitude in the year
+func solarTermDay(year int, longitude float64) int {
+	return beijingDayNumber(solarTermJDE(year, longitude))
+}
+
+// solarTermJDE julian ephemeris day of the moment when the apparent longitude of the sun
+// reaches the longitude in the year
+func solarTermJDE(year int, longitude float64) float64 {
+	// the sun is at about 280° at the beginning of a year
+	jde := float64(dayNumber(NewDate(year, 1, 1))) - 0.5 + math.Mod(longitude-280+360, 360)/360*tropicalYear
+	for i := 0; i < 10; i++ {
+		diff := math.Mod(longitude-sunApparentLongitude(jde)+540, 360) - 180
+		jde += diff / 360 * tropicalYear
+		if math.Abs(diff) < 1e-7 {
+			break
+		}
+	}
+
+	return jde
+}
+
+// sunApparentLongitude apparent geocentric longitude of the sun in degrees,
+// Meeus, Astronomical Algorithms, chapter 25, with the abridged VSOP87 series
+func sunApparentLongitude(jde float64) float64 {
+	tau := (jde - j2000) / 365250
+	t := tau * 10
+
+	l := vsop87(earthL, tau)/degreesToRadian + 180
+	r := vsop87(earthR, tau)
+	// FK5 correction, nutation and aberration
+	l += (-0.09033 + nutationInLongitude(t) - 20.4898/r) / 3600
+
+	return math.Mod(math.Mod(l, 360)+360, 360)
+}
+
+// nutationInLongitude in arc seconds, Meeus chapter 22, accurate to 0.5"
+func nutationInLongitude(t float64) float64 {
+	ls := (280.4665 + 36000.7698*t) * degreesToRadian
+	lm := (218.3165 + 481267.8813*t) * degreesToRadian
+	omega := (125.04452 - 1934.136261*t) * degreesToRadian
+
+	return -17.20*math.Sin(omega) - 1.32*math.Sin(2*ls) - 0.23*math.Sin(2*lm) + 0.21*math.Sin(2*omega)
+}
+
+// newMoonIndex index of the last new moon on or before the day, 0 is the new moon of 2000-01-06
+func newMoonIndex(day int) int {
+	k := int(math.Floor((float64(day) - 2451550.1) / synodicMonth))
+	for newMoonDay(k) > day {
+		k--
+	}
+	for newMoonDay(k+1) <= day {
+		k++
+	}
+
+	return k
+}
+
+func newMoonDay(k int) int {
+	return beijingDayNumber(newMoonJDE(float64(k)))
+}
+
+// newMoonJDE julian ephemeris day of the new moon, Meeus chapter 49
+func newMoonJDE(k float64) float64 {
+	t := k / 1236.85
+	t2, t3, t4 := t*t, t*t*t, t*t*t*t
+	jde := 2451550.09766 + synodicMonth*k + 0.00015437*t2 - 0.000000150*t3 + 0.00000000073*t4
+
+	e := 1 - 0.002516*t - 0.0000074*t2
+	m := (2.5534 + 29.10535670*k - 0.0000014*t2 - 0.00000011*t3) * degreesToRadian
+	mp := (201.5643 + 385.81693528*k + 0.0107582*t2 + 0.00001238*t3 - 0.000000058*t4) * degreesToRadian
+	f := (160.7108 + 390.67050284*k - 0.0016118*t2 - 0.00000227*t3 + 0.000000011*t4) * degreesToRadian
+	omega := (124.7746 - 1.56375588*k + 0.0020672*t2 + 0.00000215*t3) * degreesToRadian
+
+	jde += -0.40720*math.Sin(mp) +
+		0.17241*e*math.Sin(m) +
+		0.01608*math.Sin(2*mp) +
+		0.01039*math.Sin(2*f) +
+		0.00739*e*math.Sin(mp-m) -
+		0.00514*e*math.Sin(mp+m) +
+		0.00208*e*e*math.Sin(2*m) -
+		0.00111*math.Sin(mp-2*f) -
+		0.00057*math.Sin(mp+2*f) +
+		0.00056*e*math.Sin(2*mp+m) -
+		0.00042*math.Sin(3*mp) +
+		0.00042*e*math.Sin(m+2*f) +
+		0.00038*e*math.Sin(m-2*f) -
+		0.00024*e*math.Sin(2*mp-m) -
+		0.00017*math.Sin(omega) -
+		0.00007*math.Sin(mp+2*m) +
+		0.00004*math.Sin(2*mp-2*f) +
+		0.00004*math.Sin(3*m) +
+		0.00003*math.Sin(mp+m-2*f) +
+		0.00003*math.Sin(2*mp+2*f) -
+		0.00003*math.Sin(mp+m+2*f) +
+		0.00003*math.Sin(mp-m+2*f) -
+		0.00002*math.Sin(mp-m-2*f) -
+		0.00002*math.Sin(3*mp+m) +
+		0.00002*math.Sin(4*mp)
+
+	// planetary arguments
+	for _, a := range newMoonPlanetaryTerms {
+		jde += a[0] * math.Sin((a[1]+a[2]*k+a[3]*t2)*degreesToRadian)
+	}
+
+	return jde
+}
+
+var newMoonPlanetaryTerms = [][4]float64{
+	{0.000325, 299.77, 0.107408, -0.009173},
+	{0.000165, 251.88, 0.016321, 0},
+	{0.000164, 251.83, 26.651886, 0},
+	{0.000126, 349.42, 36.412478, 0},
+	{0.000110, 84.66, 18.206239, 0},
+	{0.000062, 141.74, 53.303771, 0},
+	{0.000060, 207.14, 2.453732, 0},
+	{0.000056, 154.84, 7.306860, 0},
+	{0.000047, 34.52, 27.261239, 0},
+	{0.000042, 207.19, 0.121824, 0},
+	{0.000040, 291.34, 1.844379, 0},
+	{0.000037, 161.72, 24.198154, 0},
+	{0.000035, 239.56, 25.513099, 0},
+	{0.000023, 331.55, 3.592518, 0},
+}
+
+// deltaT TT - UT in seconds, polynomial expressions by Espenak and Meeus
+func deltaT(jde float64) float64 {
+	y := 2000 + (jde-j2000)/365.25
+	switch {
+	case y < 1700:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u
+	case y < 1800:
+		t := y - 1700
+		return 8.83 + 0.1603*t - 0.0059285*t*t + 0.00013336*t*t*t - t*t*t*t/1174000
+	case y < 1860:
+		t := y - 1800
+		return 13.72 - 0.332447*t + 0.0068612*math.Pow(t, 2) + 0.0041116*math.Pow(t, 3) -
+			0.00037436*math.Pow(t, 4) + 0.0000121272*math.Pow(t, 5) - 0.0000001699*math.Pow(t, 6) +
+			0.000000000875*math.Pow(t, 7)
+	case y < 1900:
+		t := y - 1860
+		return 7.62 + 0.5737*t - 0.251754*math.Pow(t, 2) + 0.01680668*math.Pow(t, 3) -
+			0.0004473624*math.Pow(t, 4) + math.Pow(t, 5)/233174
+	case y < 1920:
+		t := y - 1900
+		return -2.79 + 1.494119*t - 0.0598939*math.Pow(t, 2) + 0.0061966*math.Pow(t, 3) - 0.000197*math.Pow(t, 4)
+	case y < 1941:
+		t := y - 1920
+		return 21.20 + 0.84493*t - 0.076100*math.Pow(t, 2) + 0.0020936*math.Pow(t, 3)
+	case y < 1961:
+		t := y - 1950
+		return 29.07 + 0.407*t - math.Pow(t, 2)/233 + math.Pow(t, 3)/2547
+	case y < 1986:
+		t := y - 1975
+		return 45.45 + 1.067*t - math.Pow(t, 2)/260 - math.Pow(t, 3)/718
+	case y < 2005:
+		t := y - 2000
+		return 63.86 + 0.3345*t - 0.060374*math.Pow(t, 2) + 0.0017275*math.Pow(t, 3) +
+			0.000651814*math.Pow(t, 4) + 0.00002373599*math.Pow(t, 5)
+	case y < 2050:
+		t := y - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	case y < 2150:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-y)
+	}
+
+	u := (y - 1820) / 100
+	return -20 + 32*u*u
+}
+
+// vsop87 evaluates the series in units of 1e-8, tau in julian millennia from J2000
+func vsop87(series [][][3]float64, tau float64) float64 {
+	var sum, power float64 = 0, 1
+	for _, terms := range series {
+		var s float64
+		for _, term := range terms {
+			s += term[0] * math.Cos(term[1]+term[2]*tau)
+		}
+		sum += s * power
+		power *= tau
+	}
+
+	return sum / 1e8
+}
+
+// earthL heliocentric longitude of the earth, abridged VSOP87 from Meeus appendix III
+var earthL = [][][3]float64{
+	{
+		{175347046, 0, 0},
+		{3341656, 4.6692568, 6283.0758500},
+		{34894, 4.62610, 12566.15170},
+		{3497, 2.7441, 5753.3849},
+		{3418, 2.8289, 3.5231},
+		{3136, 3.6277, 77713.7715},
+		{2676, 4.4181, 7860.4194},
+		{2343, 6.1352, 3930.2097},
+		{1324, 0.7425, 11506.7698},
+		{1273, 2.0371, 529.6910},
+		{1199, 1.1096, 1577.3435},
+		{990, 5.233, 5884.927},
+		{902, 2.045, 26.298},
+		{857, 3.508, 398.149},
+		{780, 1.179, 5223.694},
+		{753, 2.533, 5507.553},
+		{505, 4.583, 18849.228},
+		{492, 4.205, 775.523},
+		{357, 2.920, 0.067},
+		{317, 5.849, 11790.629},
+		{284, 1.899, 796.298},
+		{271, 0.315, 10977.079},
+		{243, 0.345, 5486.778},
+		{206, 4.806, 2544.314},
+		{205, 1.869, 5573.143},
+		{202, 2.458, 6069.777},
+		{156, 0.833, 213.299},
+		{132, 3.411, 2942.463},
+		{126, 1.083, 20.775},
+		{115, 0.645, 0.980},
+		{103, 0.636, 4694.003},
+		{102, 0.976, 15720.839},
+		{102, 4.267, 7.114},
+		{99, 6.21, 2146.17},
+		{98, 0.68, 155.42},
+		{86, 5.98, 161000.69},
+		{85, 1.30, 6275.96},
+		{85, 3.67, 71430.70},
+		{80, 1.81, 17260.15},
+		{79, 3.04, 12036.46},
+		{75, 1.76, 5088.63},
+		{74, 3.50, 3154.69},
+		{74, 4.68, 801.82},
+		{70, 0.83, 9437.76},
+		{62, 3.98, 8827.39},
+		{61, 1.82, 7084.90},
+		{57, 2.78, 6286.60},
+		{56, 4.39, 14143.50},
+		{56, 3.47, 6279.55},
+		{52, 0.19, 12139.55},
+		{52, 1.33, 1748.02},
+		{51, 0.28, 5856.48},
+		{49, 0.49, 1194.45},
+		{41, 5.37, 8429.24},
+		{41, 2.40, 19651.05},
+		{39, 6.17, 10447.39},
+		{37, 6.04, 10213.29},
+		{37, 2.57, 1059.38},
+		{36, 1.71, 2352.87},
+		{36, 1.78, 6812.77},
+		{33, 0.59, 17789.85},
+		{30, 0.44, 83996.85},
+		{30, 2.74, 1349.87},
+		{25, 3.16, 4690.48},
+	},
+	{
+		{628331966747, 0, 0},
+		{206059, 2.678235, 6283.075850},
+		{4303, 2.6351, 12566.1517},
+		{425, 1.590, 3.523},
+		{119, 5.796, 26.298},
+		{109, 2.966, 1577.344},
+		{93, 2.59, 18849.23},
+		{72, 1.14, 529.69},
+		{68, 1.87, 398.15},
+		{67, 4.41, 5507.55},
+		{59, 2.89, 5223.69},
+		{56, 2.17, 155.42},
+		{45, 0.40, 796.30},
+		{36, 0.47, 775.52},
+		{29, 2.65, 7.11},
+		{21, 5.34, 0.98},
+		{19, 1.85, 5486.78},
+		{19, 4.97, 213.30},
+		{17, 2.99, 6275.96},
+		{16, 0.03, 2544.31},
+		{16, 1.43, 2146.17},
+		{15, 1.21, 10977.08},
+		{12, 2.83, 1748.02},
+		{12, 3.26, 5088.63},
+		{12, 5.27, 1194.45},
+		{12, 2.08, 4694.00},
+		{11, 0.77, 553.57},
+		{10, 1.30, 6286.60},
+		{10, 4.24, 1349.87},
+		{9, 2.70, 242.73},
+		{9, 5.64, 951.72},
+		{8, 5.30, 2352.87},
+		{6, 2.65, 9437.76},
+		{6, 4.67, 4690.48},
+	},
+	{
+		{52919, 0, 0},
+		{8720, 1.0721, 6283.0758},
+		{309, 0.867, 12566.152},
+		{27, 0.05, 3.52},
+		{16, 5.19, 26.30},
+		{16, 3.68, 155.42},
+		{10, 0.76, 18849.23},
+		{9, 2.06, 77713.77},
+		{7, 0.83, 775.52},
+		{5, 4.66, 1577.34},
+		{4, 1.03, 7.11},
+		{4, 3.44, 5573.14},
+		{3, 5.14, 796.30},
+		{3, 6.05, 5507.55},
+		{3, 1.19, 242.73},
+		{3, 6.12, 529.69},
+		{3, 0.31, 398.15},
+		{3, 2.28, 553.57},
+		{2, 4.38, 5223.69},
+		{2, 3.75, 0.98},
+	},
+	{
+		{289, 5.844, 6283.076},
+		{35, 0, 0},
+		{17, 5.49, 12566.15},
+		{3, 5.20, 155.42},
+		{1, 4.72, 3.52},
+		{1, 5.30, 18849.23},
+		{1, 5.97, 242.73},
+	},
+	{
+		{114, 3.142, 0},
+		{8, 4.13, 6283.08},
+		{1, 3.84, 12566.15},
+	},
+	{
+		{1, 3.14, 0},
+	},
+}
+
+// earthR radius vector of the earth, only the leading terms are needed by the aberration
+var earthR = [][][3]float64{
+	{
+		{100013989, 0, 0},
+		{1670700, 3.0984635, 6283.0758500},
+		{13956, 3.05525, 12566.15170},
+		{3084, 5.1985, 77713.7715},
+		{1628, 1.1739, 5753.3849},
+		{1576, 2.8469, 7860.4194},
+	},
+	{
+		{103019, 1.107490, 6283.075850},
+		{1721, 1.0644, 12566.1517},
+	},
+	{
+		{4359, 5.7846, 6283.0758},
+	},
+}
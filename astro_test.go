@@ -0,0 +1,70 @@
+package lunar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAstronomical(t *testing.T) {
+	// before 1929 the HKO tables differ on a few new moons and solar terms
+	// close to midnight, which were not reckoned in UTC+8 at that time
+	from, to := NewDate(1929, 1, 1), NewDate(2100, 12, 31)
+	expected, err := New().Range(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := NewAstronomical().Range(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Astronomical error, expected: %d days, actual: %d days", len(expected), len(actual))
+	}
+	for i, e := range expected {
+		if a := actual[i]; a.Date != e.Date || a.LunarDate != e.LunarDate || a.SolarTerm != e.SolarTerm {
+			t.Errorf("Astronomical error, expected: %s %s %s, actual: %s %s %s",
+				e.Date, e.LunarDate, e.SolarTerm, a.Date, a.LunarDate, a.SolarTerm)
+		}
+	}
+}
+
+func TestAstronomicalRange(t *testing.T) {
+	var last *Result
+	err := NewAstronomical().RangeFunc(NewDate(1800, 1, 1), NewDate(2300, 12, 31), func(r *Result) bool {
+		if last != nil && r.LunarDate.Day != last.LunarDate.Day+1 &&
+			(r.LunarDate.Day != 1 || last.LunarDate.Day < 29) {
+			t.Errorf("AstronomicalRange error, %s is %s, %s is %s", last.Date, last.LunarDate, r.Date, r.LunarDate)
+		}
+		last = r
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetSolarTermEvents(t *testing.T) {
+	events, err := GetSolarTermEvents(2021, "冬至")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2021-12-21 23:59 CST
+	expected := time.Date(2021, 12, 21, 15, 59, 0, 0, time.UTC)
+	if len(events) != 1 || events[0].Time.Sub(expected) > time.Minute || expected.Sub(events[0].Time) > time.Minute {
+		t.Fatalf("GetSolarTermEvents error, expected: %s, actual: %+v", expected, events)
+	}
+
+	cst := time.FixedZone("CST", 8*3600)
+	for year := 1929; year < 2100; year++ {
+		events, err := GetSolarTermEvents(year)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range events {
+			if d := DateByTime(e.Time.In(cst)); d != e.Result.Date {
+				t.Errorf("GetSolarTermEvents error, expected: %s, actual: %s", e.Result.Date, d)
+			}
+		}
+	}
+}
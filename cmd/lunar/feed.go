@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/xwjdsh/lunar"
+	"github.com/xwjdsh/lunar/alias"
+	"github.com/xwjdsh/lunar/config"
+)
+
+// icsFeed serves aliases and solar terms of a rolling window of years as an iCalendar feed,
+// aliases are reloaded when the config file changes
+type icsFeed struct {
+	lh          *lunar.Handler
+	configPath  string
+	yearsBefore int
+	yearsAfter  int
+
+	mu      sync.Mutex
+	h       *alias.Handler
+	modTime time.Time
+	// stamp the modification time of the config file, or the start time if the file does not exist,
+	// the feed uses the later one of it and the beginning of the current year
+	stamp time.Time
+}
+
+func newICSFeed(lh *lunar.Handler, configPath string, yearsBefore, yearsAfter int) (*icsFeed, error) {
+	f := &icsFeed{
+		lh:          lh,
+		configPath:  configPath,
+		yearsBefore: yearsBefore,
+		yearsAfter:  yearsAfter,
+	}
+	if _, _, err := f.handler(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// handler returns the alias handler and the modification time of the config file,
+// the config is reloaded if the file has changed since last load
+func (f *icsFeed) handler() (*alias.Handler, time.Time, error) {
+	var modTime time.Time
+	fi, err := os.Stat(f.configPath)
+	if err == nil {
+		modTime = fi.ModTime()
+	} else if !os.IsNotExist(err) {
+		return nil, time.Time{}, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.h != nil && modTime.Equal(f.modTime) {
+		return f.h, f.stamp, nil
+	}
+
+	conf, err := config.Init(f.configPath, false)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	h := alias.NewHandler(f.lh)
+	if err := h.LoadAlias(conf.Aliases); err != nil {
+		return nil, time.Time{}, err
+	}
+	if f.h != nil {
+		log.Printf("reloaded %s", f.configPath)
+	}
+
+	f.h, f.modTime, f.stamp = h, modTime, modTime
+	if modTime.IsZero() {
+		f.stamp = time.Now()
+	}
+
+	return f.h, f.stamp, nil
+}
+
+func (f *icsFeed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	h, stamp, err := f.handler()
+	if err != nil {
+		log.Println(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// the window rolls at each new year, so the feed is modified by
+	// either the config or the beginning of the current year
+	year := currentDate(nil).Year
+	if yearStart := time.Date(year, 1, 1, 0, 0, 0, 0, _CST); yearStart.After(stamp) {
+		stamp = yearStart
+	}
+	events, err := getICSEvents(h, year-f.yearsBefore, year+f.yearsAfter)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if tags := r.URL.Query()["tag"]; len(tags) > 0 {
+		events = filterICSEvents(events, tags)
+	}
+
+	var buf bytes.Buffer
+	if err := writeICS(&buf, events, stamp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// the content changes with the config, the year window and the tags,
+	// so the ETag of the content lets clients skip unchanged feeds
+	hash := fnv.New64a()
+	_, _ = hash.Write(buf.Bytes())
+	w.Header().Set("ETag", fmt.Sprintf(`"%016x"`, hash.Sum64()))
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeContent(w, r, "calendar.ics", stamp, bytes.NewReader(buf.Bytes()))
+}
+
+// filterICSEvents returns events in any of the tags
+func filterICSEvents(events []*icsEvent, tags []string) []*icsEvent {
+	tagMap := map[string]bool{}
+	for _, t := range tags {
+		tagMap[t] = true
+	}
+
+	filtered := []*icsEvent{}
+	for _, e := range events {
+		for _, c := range e.categories {
+			if tagMap[c] {
+				filtered = append(filtered, e)
+				break
+			}
+		}
+	}
+
+	return filtered
+}
+
+// serveICS serves the iCalendar feed at /calendar.ics until SIGINT or SIGTERM
+func serveICS(f *icsFeed, addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/calendar.ics", f)
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return runServer(srv)
+}
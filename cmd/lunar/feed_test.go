@@ -0,0 +1,92 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xwjdsh/lunar"
+)
+
+func TestICSFeed(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "lunar.yml")
+	writeConfig := func(content string, modTime time.Time) {
+		if err := os.WriteFile(fp, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(fp, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeConfig("aliases:\n  - name: 生日\n    date: {month: 5, day: 7}\n    is_lunar_date: true\n", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	f, err := newICSFeed(lunar.New(), fp, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := func(target string, header http.Header) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		for k, v := range header {
+			r.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		f.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("/calendar.ics", nil)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "SUMMARY:生日") {
+		t.Fatalf("ICSFeed error, expected: 200 with the alias, actual: %d %s", w.Code, w.Body)
+	}
+	etag := w.Header().Get("ETag")
+	// the config is older than the year window
+	yearStart := time.Date(currentDate(nil).Year, 1, 1, 0, 0, 0, 0, _CST)
+	if actual := w.Header().Get("Last-Modified"); actual != yearStart.UTC().Format(http.TimeFormat) {
+		t.Errorf("ICSFeed error, expected: Last-Modified %s, actual: %s", yearStart.UTC().Format(http.TimeFormat), actual)
+	}
+
+	for _, c := range []struct {
+		name   string
+		header http.Header
+		status int
+	}{
+		{"If-None-Match", http.Header{"If-None-Match": {etag}}, http.StatusNotModified},
+		{"If-None-Match of another ETag", http.Header{"If-None-Match": {`"0"`}}, http.StatusOK},
+		{"If-Modified-Since", http.Header{"If-Modified-Since": {w.Header().Get("Last-Modified")}}, http.StatusNotModified},
+		{"If-Modified-Since before the year", http.Header{"If-Modified-Since": {yearStart.Add(-time.Hour).UTC().Format(http.TimeFormat)}}, http.StatusOK},
+	} {
+		if actual := get("/calendar.ics", c.header).Code; actual != c.status {
+			t.Errorf("ICSFeed error, %s, expected: %d, actual: %d", c.name, c.status, actual)
+		}
+	}
+
+	w = get("/calendar.ics?tag=solar-term", nil)
+	if body := w.Body.String(); strings.Contains(body, "生日") || !strings.Contains(body, "CATEGORIES:solar-term") {
+		t.Errorf("ICSFeed error, expected: solar terms only, actual: %s", body)
+	}
+	if w.Header().Get("ETag") == etag {
+		t.Errorf("ICSFeed error, expected: ETag changes with the tags, actual: %s", etag)
+	}
+
+	// the config is reloaded once it changes
+	modTime := time.Now().Add(time.Hour).Truncate(time.Second)
+	writeConfig("aliases:\n  - name: 纪念日\n    date: {month: 6, day: 1}\n", modTime)
+	w = get("/calendar.ics", http.Header{"If-None-Match": {etag}})
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "SUMMARY:纪念日") {
+		t.Errorf("ICSFeed error, expected: 200 with the reloaded alias, actual: %d %s", w.Code, w.Body)
+	}
+	if actual := w.Header().Get("Last-Modified"); actual != modTime.UTC().Format(http.TimeFormat) {
+		t.Errorf("ICSFeed error, expected: Last-Modified %s, actual: %s", modTime.UTC().Format(http.TimeFormat), actual)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/calendar.ics", nil)
+	w = httptest.NewRecorder()
+	f.ServeHTTP(w, r)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("ICSFeed error, expected: %d, actual: %d", http.StatusMethodNotAllowed, w.Code)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xwjdsh/lunar"
+	"github.com/xwjdsh/lunar/alias"
+)
+
+const (
+	icsDateFormat     = "20060102"
+	icsDateTimeFormat = "20060102T150405Z"
+	// icsLineLength lines longer than it are folded, in octets
+	icsLineLength = 75
+	// icsSolarTermCategory category of solar term events
+	icsSolarTermCategory = "solar-term"
+)
+
+// icsEvent an all-day event
+type icsEvent struct {
+	uid         string
+	date        lunar.Date
+	summary     string
+	description string
+	categories  []string
+}
+
+// getICSEvents returns events of aliases and solar terms of years from and to, both inclusive
+func getICSEvents(h *alias.Handler, from, to int) ([]*icsEvent, error) {
+	events := []*icsEvent{}
+	for year := from; year <= to; year++ {
+		rs, err := h.GetAliases(year)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rs {
+			for _, a := range r.Aliases {
+				events = append(events, &icsEvent{
+					uid:        icsUID("alias", r.Date, a.Name),
+					date:       r.Date,
+					summary:    a.Name,
+					categories: a.Tags,
+				})
+			}
+		}
+
+		days, err := h.Range(lunar.NewDate(year, 1, 1), lunar.NewDate(year, 12, 31))
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range days {
+			if !r.Term.Valid() {
+				continue
+			}
+			events = append(events, &icsEvent{
+				uid:         icsUID("solar-term", r.Date, r.Term.Pinyin()),
+				date:        r.Date,
+				summary:     r.Term.Name(locale),
+				description: r.Term.Time(year).Format(time.RFC3339),
+				categories:  []string{icsSolarTermCategory},
+			})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		ti, tj := events[i].date.Time(), events[j].date.Time()
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return events[i].uid < events[j].uid
+	})
+
+	return events, nil
+}
+
+// icsUID is stable for the same event, eg. alias-20240210-1f2e3d4c@lunar
+func icsUID(kind string, d lunar.Date, name string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name))
+	return fmt.Sprintf("%s-%s-%08x@lunar", kind, d, hash.Sum32())
+}
+
+// writeICS writes events as an RFC 5545 calendar, stamp is the DTSTAMP of events
+func writeICS(w io.Writer, events []*icsEvent, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		for len(s) > icsLineLength {
+			i := icsLineLength
+			// do not split UTF-8 sequences
+			for i > 0 && s[i]&0xc0 == 0x80 {
+				i--
+			}
+			bw.WriteString(s[:i] + "\r\n")
+			s = " " + s[i:]
+		}
+		bw.WriteString(s + "\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//xwjdsh//lunar//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:lunar")
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + e.uid)
+		line("DTSTAMP:" + stamp.UTC().Format(icsDateTimeFormat))
+		line("DTSTART;VALUE=DATE:" + e.date.Time().Format(icsDateFormat))
+		line("DTEND;VALUE=DATE:" + e.date.Time().AddDate(0, 0, 1).Format(icsDateFormat))
+		line("SUMMARY:" + icsEscape(e.summary))
+		if e.description != "" {
+			line("DESCRIPTION:" + icsEscape(e.description))
+		}
+		if len(e.categories) > 0 {
+			categories := make([]string, len(e.categories))
+			for i, c := range e.categories {
+				categories[i] = icsEscape(c)
+			}
+			line("CATEGORIES:" + strings.Join(categories, ","))
+		}
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	return bw.Flush()
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+func icsEscape(s string) string {
+	return icsEscaper.Replace(s)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/xwjdsh/lunar"
+)
+
+func TestICSEscape(t *testing.T) {
+	for s, expected := range map[string]string{
+		"春节":          "春节",
+		`a\b`:         `a\\b`,
+		"a;b,c":       `a\;b\,c`,
+		"line\nbreak": `line\nbreak`,
+	} {
+		if actual := icsEscape(s); actual != expected {
+			t.Errorf("icsEscape error, expected: %s, actual: %s", expected, actual)
+		}
+	}
+}
+
+func TestWriteICS(t *testing.T) {
+	summary := strings.Repeat("春节,", 30)
+	events := []*icsEvent{{
+		uid:        icsUID("alias", lunar.NewDate(2024, 2, 10), "春节"),
+		date:       lunar.NewDate(2024, 2, 10),
+		summary:    summary,
+		categories: []string{"holiday", "a;b"},
+	}}
+
+	var buf bytes.Buffer
+	if err := writeICS(&buf, events, time.Date(2024, 1, 1, 8, 0, 0, 0, _CST)); err != nil {
+		t.Fatal(err)
+	}
+
+	content := buf.String()
+	if !strings.HasSuffix(content, "END:VCALENDAR\r\n") {
+		t.Errorf("writeICS error, expected: lines end with CRLF, actual: %q", content)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(content, "\r\n"), "\r\n") {
+		if len(line) > icsLineLength || !utf8.ValidString(line) {
+			t.Errorf("writeICS error, expected: folded UTF-8 lines of %d octets at most, actual: %q", icsLineLength, line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(content, "\r\n ", "")
+	for _, expected := range []string{
+		"SUMMARY:" + icsEscape(summary) + "\r\n",
+		"DTSTAMP:20240101T000000Z\r\n",
+		"DTSTART;VALUE=DATE:20240210\r\n",
+		"DTEND;VALUE=DATE:20240211\r\n",
+		`CATEGORIES:holiday,a\;b` + "\r\n",
+	} {
+		if !strings.Contains(unfolded, expected) {
+			t.Errorf("writeICS error, expected: %q, actual: %q", expected, unfolded)
+		}
+	}
+}
+
+func TestGetICSEvents(t *testing.T) {
+	events, err := getICSEvents(newTestHandler(t), 2024, 2024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := map[string]lunar.Date{}
+	for i, e := range events {
+		if i > 0 && e.date.Time().Before(events[i-1].date.Time()) {
+			t.Errorf("getICSEvents error, expected: sorted by date, actual: %s after %s", e.date, events[i-1].date)
+		}
+		found[e.summary] = e.date
+	}
+	for name, expected := range map[string]lunar.Date{
+		"春节": lunar.NewDate(2024, 2, 10),
+		"除夕": lunar.NewDate(2024, 2, 9),
+		"立春": lunar.NewDate(2024, 2, 4),
+	} {
+		if actual := found[name]; actual != expected {
+			t.Errorf("getICSEvents error, event: %s, expected: %s, actual: %s", name, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/xwjdsh/lunar"
+)
+
+// lunarMonthInfo a month of the lunar year
+type lunarMonthInfo struct {
+	month    lunar.LunarDate
+	days     int
+	firstDay lunar.Date
+}
+
+// getLunarMonths returns months of the lunar year in order, the leap month follows its month
+func getLunarMonths(h *lunar.Handler, year int) ([]*lunarMonthInfo, error) {
+	leapMonth, err := h.LeapMonth(year)
+	if err != nil {
+		return nil, err
+	}
+
+	months := []*lunarMonthInfo{}
+	for month := 1; month <= 12; month++ {
+		for _, isLeapMonth := range []bool{false, true} {
+			if isLeapMonth && month != leapMonth {
+				continue
+			}
+			d := lunar.NewLunarDate(lunar.NewDate(year, month, 1), isLeapMonth)
+			r, err := h.Calendar(d)
+			if err != nil {
+				return nil, err
+			}
+			days, err := h.LunarMonthDays(year, month, isLeapMonth)
+			if err != nil {
+				return nil, err
+			}
+			months = append(months, &lunarMonthInfo{month: d, days: days, firstDay: r.Date})
+		}
+	}
+
+	return months, nil
+}
+
+// outputInfo prints the month table of the lunar year
+func outputInfo(h *lunar.Handler, year int, dateFormat string) error {
+	months, err := getLunarMonths(h, year)
+	if err != nil {
+		return err
+	}
+
+	leapMonth, total := 0, 0
+	data := make([][]string, len(months))
+	for i, m := range months {
+		if m.month.IsLeapMonth {
+			leapMonth = m.month.Month
+		}
+		total += m.days
+		size := msg().smallMonth
+		if m.days == 30 {
+			size = msg().bigMonth
+		}
+		data[i] = []string{m.month.MonthNameIn(locale), size, strconv.Itoa(m.days), m.firstDay.Time().Format(dateFormat)}
+	}
+
+	fmt.Println(lunarYearTitle(year, leapMonth, total))
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(msg().infoHeader)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.AppendBulk(data)
+	table.Render()
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xwjdsh/lunar"
+)
+
+var (
+	relativePattern = regexp.MustCompile(`^([+-]\d+)([dwmy])$`)
+	weekdayNameMap  = map[string]time.Weekday{}
+)
+
+func init() {
+	for w := time.Sunday; w <= time.Saturday; w++ {
+		name := strings.ToLower(w.String())
+		weekdayNameMap[name] = w
+		weekdayNameMap[name[:3]] = w
+	}
+}
+
+// splitDateArgs joins the weekday modifiers with the following weekday, eg. next friday,
+// flags after dates are not parsed by cli, they and the rest are returned as ignored,
+// eg. -y 2020 of lunar -r 0420 -y 2020, negative offsets like -2w are dates
+func splitDateArgs(args []string) ([]string, []string) {
+	result := []string{}
+	for i := 0; i < len(args); i++ {
+		if strings.HasPrefix(args[i], "-") && !relativePattern.MatchString(args[i]) {
+			return result, args[i:]
+		}
+		switch strings.ToLower(args[i]) {
+		case "next", "last", "this":
+			if i+1 < len(args) {
+				result = append(result, args[i]+" "+args[i+1])
+				i++
+				continue
+			}
+		}
+		result = append(result, args[i])
+	}
+
+	return result, nil
+}
+
+// parseDate parses gregorian dates like MMDD (in the year of d), YYYYMMDD, YYYY-MM-DD,
+// today, tomorrow, yesterday, +30d, -2w, +1m, +1y, friday, next friday and last friday
+func parseDate(s string, d lunar.Date) (lunar.Date, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	today := currentDate(nil)
+	switch s {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return addDays(today, 1), nil
+	case "yesterday":
+		return addDays(today, -1), nil
+	}
+
+	if m := relativePattern.FindStringSubmatch(s); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return d, err
+		}
+		t := today.Time()
+		switch m[2] {
+		case "d":
+			t = t.AddDate(0, 0, n)
+		case "w":
+			t = t.AddDate(0, 0, 7*n)
+		case "m":
+			t = t.AddDate(0, n, 0)
+		case "y":
+			t = t.AddDate(n, 0, 0)
+		}
+		return lunar.DateByTime(t), nil
+	}
+
+	if w, ok := parseWeekday(s, today); ok {
+		return w, nil
+	}
+
+	for _, layout := range []string{"2006-01-02", "2006/01/02", "20060102"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return lunar.DateByTime(t), nil
+		}
+	}
+
+	t, err := time.Parse("0102", s)
+	if err != nil {
+		return d, fmt.Errorf("invalid date %q", s)
+	}
+	d.Month, d.Day = int(t.Month()), t.Day()
+
+	return d, nil
+}
+
+// parseWeekday friday is the coming one including today, next friday is after today,
+// last friday is before today
+func parseWeekday(s string, today lunar.Date) (lunar.Date, bool) {
+	modifier, name := "this", s
+	if fields := strings.Fields(s); len(fields) == 2 {
+		modifier, name = fields[0], fields[1]
+	}
+	w, ok := weekdayNameMap[name]
+	if !ok {
+		return today, false
+	}
+
+	offset := (int(w) - int(today.Time().Weekday()) + 7) % 7
+	switch modifier {
+	case "this":
+	case "next":
+		if offset == 0 {
+			offset = 7
+		}
+	case "last":
+		offset -= 7
+	default:
+		return today, false
+	}
+
+	return addDays(today, offset), true
+}
+
+func addDays(d lunar.Date, n int) lunar.Date {
+	return lunar.DateByTime(d.Time().AddDate(0, 0, n))
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xwjdsh/lunar"
+)
+
+func TestSplitDateArgs(t *testing.T) {
+	for _, c := range []struct {
+		args, expected, ignored []string
+	}{
+		{[]string{}, []string{}, nil},
+		{[]string{"next", "friday", "0420"}, []string{"next friday", "0420"}, nil},
+		{[]string{"+30d", "-2w", "+1m"}, []string{"+30d", "-2w", "+1m"}, nil},
+		{[]string{"0420", "-y", "2020"}, []string{"0420"}, []string{"-y", "2020"}},
+		{[]string{"last"}, []string{"last"}, nil},
+	} {
+		actual, ignored := splitDateArgs(c.args)
+		if !reflect.DeepEqual(actual, c.expected) || !reflect.DeepEqual(ignored, c.ignored) {
+			t.Errorf("splitDateArgs error, args: %v, expected: %q %q, actual: %q %q", c.args, c.expected, c.ignored, actual, ignored)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	today := currentDate(nil)
+	d := lunar.NewDate(2020, 1, 1)
+	for s, expected := range map[string]lunar.Date{
+		"2024-02-10": lunar.NewDate(2024, 2, 10),
+		"2024/02/10": lunar.NewDate(2024, 2, 10),
+		"20250129":   lunar.NewDate(2025, 1, 29),
+		"0420":       lunar.NewDate(2020, 4, 20),
+		"today":      today,
+		"Tomorrow":   addDays(today, 1),
+		"yesterday":  addDays(today, -1),
+		"+30d":       addDays(today, 30),
+		"-2w":        addDays(today, -14),
+		"+1m":        lunar.DateByTime(today.Time().AddDate(0, 1, 0)),
+		"-1y":        lunar.DateByTime(today.Time().AddDate(-1, 0, 0)),
+	} {
+		actual, err := parseDate(s, d)
+		if err != nil {
+			t.Errorf("parseDate error, date: %s, %v", s, err)
+			continue
+		}
+		if actual != expected {
+			t.Errorf("parseDate error, date: %s, expected: %s, actual: %s", s, expected, actual)
+		}
+	}
+
+	for _, s := range []string{"", "2024-13-01", "1332", "+30", "30d", "next"} {
+		if _, err := parseDate(s, d); err == nil {
+			t.Errorf("parseDate error, date: %q, expected: invalid date error, actual: nil", s)
+		}
+	}
+}
+
+func TestParseWeekday(t *testing.T) {
+	// 2024-02-14 is a wednesday
+	today := lunar.NewDate(2024, 2, 14)
+	for _, c := range []struct {
+		s        string
+		expected lunar.Date
+		ok       bool
+	}{
+		{"friday", lunar.NewDate(2024, 2, 16), true},
+		{"fri", lunar.NewDate(2024, 2, 16), true},
+		{"wednesday", lunar.NewDate(2024, 2, 14), true},
+		{"this monday", lunar.NewDate(2024, 2, 19), true},
+		{"next wednesday", lunar.NewDate(2024, 2, 21), true},
+		{"next friday", lunar.NewDate(2024, 2, 16), true},
+		{"last wednesday", lunar.NewDate(2024, 2, 7), true},
+		{"last friday", lunar.NewDate(2024, 2, 9), true},
+		{"someday", today, false},
+		{"after friday", today, false},
+	} {
+		d, ok := parseWeekday(c.s, today)
+		if d != c.expected || ok != c.ok {
+			t.Errorf("parseWeekday error, weekday: %s, expected: %s %v, actual: %s %v", c.s, c.expected, c.ok, d, ok)
+		}
+	}
+}
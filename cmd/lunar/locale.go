@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/xwjdsh/lunar"
+)
+
+// locale output language, set by the --lang flag or the environment
+var locale = lunar.LocaleSimplified
+
+type messages struct {
+	header    []string
+	zodiac    string
+	moment    string
+	today     string
+	daysLeft  string
+	daysAgo   string
+	leapMonth string
+	weekdays  []string
+	// shortMonths two characters month names, see shortMonthName
+	shortMonths []string
+	leap        string
+	// infoHeader header of the month table of the info command
+	infoHeader []string
+	bigMonth   string
+	smallMonth string
+}
+
+var localeMessages = map[lunar.Locale]*messages{
+	lunar.LocaleSimplified: {
+		header:      []string{"阳历", "阴历", "星期", "距今", "节气", "别名", "标签"},
+		zodiac:      "生肖",
+		moment:      "时刻",
+		today:       "今天",
+		daysLeft:    "还有 %d 天",
+		daysAgo:     "已过去 %d 天",
+		leapMonth:   " (闰月)",
+		weekdays:    []string{"日", "一", "二", "三", "四", "五", "六"},
+		shortMonths: []string{"", "正", "二", "三", "四", "五", "六", "七", "八", "九", "十", "冬", "腊"},
+		leap:        "闰",
+		infoHeader:  []string{"月份", "大小", "天数", "初一"},
+		bigMonth:    "大",
+		smallMonth:  "小",
+	},
+	lunar.LocaleTraditional: {
+		header:      []string{"陽曆", "陰曆", "星期", "距今", "節氣", "別名", "標籤"},
+		zodiac:      "生肖",
+		moment:      "時刻",
+		today:       "今天",
+		daysLeft:    "還有 %d 天",
+		daysAgo:     "已過去 %d 天",
+		leapMonth:   " (閏月)",
+		weekdays:    []string{"日", "一", "二", "三", "四", "五", "六"},
+		shortMonths: []string{"", "正", "二", "三", "四", "五", "六", "七", "八", "九", "十", "冬", "臘"},
+		leap:        "閏",
+		infoHeader:  []string{"月份", "大小", "天數", "初一"},
+		bigMonth:    "大",
+		smallMonth:  "小",
+	},
+	lunar.LocaleEnglish: {
+		header:      []string{"Date", "Lunar", "Weekday", "Delta", "Solar term", "Alias", "Tag"},
+		zodiac:      "Zodiac",
+		moment:      "Moment",
+		today:       "today",
+		daysLeft:    "in %d days",
+		daysAgo:     "%d days ago",
+		leapMonth:   " (leap)",
+		weekdays:    []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+		shortMonths: []string{"", "M1", "M2", "M3", "M4", "M5", "M6", "M7", "M8", "M9", "M10", "M11", "M12"},
+		leap:        "L",
+		infoHeader:  []string{"Month", "Size", "Days", "First day"},
+		bigMonth:    "long",
+		smallMonth:  "short",
+	},
+}
+
+func msg() *messages {
+	return localeMessages[locale]
+}
+
+// setLocale uses the --lang flag, or LC_ALL, LC_MESSAGES and LANG in order,
+// unknown locales of the environment fall back to simplified chinese
+func setLocale(lang string) error {
+	if lang != "" {
+		l, err := lunar.ParseLocale(lang)
+		if err != nil {
+			return err
+		}
+		locale = l
+		return nil
+	}
+
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(key); v != "" {
+			if l, err := lunar.ParseLocale(v); err == nil {
+				locale = l
+			}
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func yearMonthTitle(year, month int) string {
+	if locale == lunar.LocaleEnglish {
+		return fmt.Sprintf("%s %d", time.Month(month), year)
+	}
+
+	return fmt.Sprintf("%d年%d月", year, month)
+}
+
+// lunarYearTitle eg. 2023 癸卯年 闰二月 共 384 天
+func lunarYearTitle(year, leapMonth, days int) string {
+	pillar := lunar.NewLunarDate(lunar.NewDate(year, 1, 1), false).FormatIn("{G}", locale)
+	switch {
+	case locale == lunar.LocaleEnglish && leapMonth == 0:
+		return fmt.Sprintf("%d %s, no leap month, %d days", year, pillar, days)
+	case locale == lunar.LocaleEnglish:
+		return fmt.Sprintf("%d %s, leap month %d, %d days", year, pillar, leapMonth, days)
+	}
+
+	leap := "无闰月"
+	if locale == lunar.LocaleTraditional {
+		leap = "無閏月"
+	}
+	if leapMonth != 0 {
+		leap = lunar.NewLunarDate(lunar.NewDate(year, leapMonth, 1), true).MonthNameIn(locale)
+	}
+
+	return fmt.Sprintf("%d %s年 %s 共 %d 天", year, pillar, leap, days)
+}
+
+func daysDelta(days int) string {
+	switch {
+	case days < 0:
+		return fmt.Sprintf(msg().daysLeft, -days)
+	case days > 0:
+		return fmt.Sprintf(msg().daysAgo, days)
+	}
+
+	return msg().today
+}
+
+func solarTermName(r *lunar.Result) string {
+	if !r.Term.Valid() {
+		return r.SolarTerm
+	}
+
+	return r.Term.Name(locale)
+}
+
+func zodiacName(z lunar.Sexagenary, l lunar.Locale) string {
+	if l == lunar.LocaleEnglish {
+		return fmt.Sprintf("%s %s %s", z.Name(l), z.Stem.Element().Name(l), z.Branch.Zodiac().Name(l))
+	}
+
+	return fmt.Sprintf("%s %s%s", z, z.Stem.Element().Name(l), z.Branch.Zodiac().Name(l))
+}
+
+// lunarDayName shows the month name on the first day of a lunar month
+func lunarDayName(d lunar.LunarDate) string {
+	switch {
+	case d.Day == 1:
+		return d.MonthNameIn(locale)
+	case locale == lunar.LocaleEnglish:
+		return strconv.Itoa(d.Day)
+	}
+
+	return d.DayNameIn(locale)
+}
@@ -5,11 +5,9 @@ import (
 	"log"
 	"os"
 	"path"
-	"sort"
 	"strings"
 	"time"
 
-	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli/v2"
 
 	"github.com/xwjdsh/lunar"
@@ -27,12 +25,12 @@ func main() {
 		if err != nil {
 			return err
 		}
-		h.LoadAlias(conf.Aliases)
-		return nil
+		return h.LoadAlias(conf.Aliases)
 	}
 	app := &cli.App{
-		Name:  "lunar",
-		Usage: "lunar is a command line tool for conversion between Gregorian calendar and lunar calendar.(1901~2100)",
+		Name:      "lunar",
+		Usage:     "lunar is a command line tool for conversion between Gregorian calendar and lunar calendar.(1901~2100)",
+		ArgsUsage: "[DATE...], eg. MMDD, YYYY-MM-DD, YYYYMMDD, today, tomorrow, +30d, next friday, 正月初一, L2024-04-20, 2024-闰04-20",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "format",
@@ -57,6 +55,29 @@ func main() {
 				Aliases: []string{"r"},
 				Usage:   "Reverse mode, query date by lunar date",
 			},
+			&cli.BoolFlag{
+				Name:    "zodiac",
+				Aliases: []string{"z"},
+				Usage:   "Show zodiac column",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   "table",
+				Usage:   "Output format, table, json, yaml, csv, tsv or markdown",
+			},
+			&cli.StringFlag{
+				Name:  "lunar-format",
+				Usage: "Output lunar date format, eg. \"{Y}年{M}{D}\", \"{G}年{N}{D}\", see LunarDate.Format",
+			},
+			&cli.StringFlag{
+				Name:    "lang",
+				Aliases: []string{"l"},
+				Usage:   "Output language, zh-Hans, zh-Hant or en, detected by LC_ALL, LC_MESSAGES and LANG if not set",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			return setLocale(c.String("lang"))
 		},
 		Commands: []*cli.Command{
 			{
@@ -90,32 +111,182 @@ func main() {
 						return err
 					}
 
-					outputResults(results, c)
-					return nil
+					return outputResults(results, c, nil)
 				},
 			},
 			{
-				Name:    "solar-term",
-				Aliases: []string{"st"},
-				Usage:   "Get solar term info",
-				Before:  beforeFunc,
+				Name:      "solar-term",
+				Aliases:   []string{"st"},
+				Usage:     "Get solar term info",
+				ArgsUsage: "[NAME...], in traditional or simplified chinese, pinyin or english",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "time",
+						Aliases: []string{"t"},
+						Usage:   "Show the exact moments in the local time zone",
+					},
+				},
+				Before: beforeFunc,
 				Action: func(c *cli.Context) error {
 					d := currentDate(c)
-					var (
-						rs  []*alias.Result
-						err error
-					)
-					if c.Args().Len() >= 1 {
-						rs, err = h.WrapResults(h.GetSolarTerms(d.Year, c.Args().Slice()...))
-					} else {
-						rs, err = h.WrapResults(h.GetSolarTerms(d.Year))
+					events, err := h.GetSolarTermEvents(d.Year, c.Args().Slice()...)
+					if err != nil {
+						return err
+					}
+
+					rs := make([]*alias.Result, len(events))
+					var times map[lunar.Date]time.Time
+					if c.Bool("time") {
+						times = map[lunar.Date]time.Time{}
+					}
+					for i, e := range events {
+						rs[i], _ = h.WrapResult(e.Result, nil)
+						if times != nil {
+							times[e.Result.Date] = e.Time.Local()
+						}
+					}
+
+					return outputResults(rs, c, times)
+				},
+			},
+			{
+				Name:      "month",
+				Aliases:   []string{"m"},
+				Usage:     "Show monthly calendar",
+				ArgsUsage: "[YYYYMM]",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "monday",
+						Aliases: []string{"m"},
+						Usage:   "Week starts on Monday",
+					},
+				},
+				Before: beforeFunc,
+				Action: func(c *cli.Context) error {
+					d := currentDate(c)
+					if s := c.Args().First(); s != "" {
+						var err error
+						if d, err = parseYearMonth(s, d); err != nil {
+							return err
+						}
+					}
+
+					return outputMonth(h, d.Year, d.Month, c.Bool("monday"))
+				},
+			},
+			{
+				Name:  "year",
+				Usage: "Show whole year calendar",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "columns",
+						Usage: "Months per row, detected by terminal width if not set",
+					},
+					&cli.BoolFlag{
+						Name:    "monday",
+						Aliases: []string{"m"},
+						Usage:   "Week starts on Monday",
+					},
+				},
+				Before: beforeFunc,
+				Action: func(c *cli.Context) error {
+					return outputYear(h, c.Int("year"), c.Int("columns"), c.Bool("monday"))
+				},
+			},
+			{
+				Name:  "info",
+				Usage: "Show months of the lunar year",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:    "year",
+						Aliases: []string{"y"},
+						Usage:   "Lunar year, the global --year if not set",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					year := c.Int("year")
+					if year == 0 {
+						year = c.Lineage()[1].Int("year")
 					}
+
+					return outputInfo(h.Handler, year, c.String("format"))
+				},
+			},
+			{
+				Name:  "export",
+				Usage: "Export aliases and solar terms",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "ics",
+						Usage: "Export as an iCalendar (RFC 5545) file to stdout",
+						Flags: []cli.Flag{
+							&cli.IntFlag{
+								Name:  "from",
+								Usage: "First year, the target year if not set",
+							},
+							&cli.IntFlag{
+								Name:  "to",
+								Usage: "Last year, the first year if not set",
+							},
+						},
+						Before: beforeFunc,
+						Action: func(c *cli.Context) error {
+							from, to, err := yearRange(c)
+							if err != nil {
+								return err
+							}
+							events, err := getICSEvents(h, from, to)
+							if err != nil {
+								return err
+							}
+
+							return writeICS(os.Stdout, events, time.Now())
+						},
+					},
+				},
+			},
+			{
+				Name:  "serve",
+				Usage: "Serve the REST API, the OpenAPI document is at /openapi.json",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "addr",
+						Value: ":8080",
+						Usage: "Listen address",
+					},
+				},
+				Before: beforeFunc,
+				Action: func(c *cli.Context) error {
+					return serve(h, c.String("addr"))
+				},
+			},
+			{
+				Name:  "serve-ics",
+				Usage: "Serve aliases and solar terms as an iCalendar feed at /calendar.ics, filtered by the tag query parameters",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "addr",
+						Value: ":8080",
+						Usage: "Listen address",
+					},
+					&cli.IntFlag{
+						Name:  "years-before",
+						Value: 1,
+						Usage: "Years before the current year in the feed",
+					},
+					&cli.IntFlag{
+						Name:  "years-after",
+						Value: 1,
+						Usage: "Years after the current year in the feed",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					f, err := newICSFeed(h.Handler, c.String("config"), c.Int("years-before"), c.Int("years-after"))
 					if err != nil {
 						return err
 					}
 
-					outputResults(rs, c)
-					return nil
+					return serveICS(f, c.String("addr"))
 				},
 			},
 			{
@@ -148,20 +319,23 @@ func main() {
 				return err
 			}
 			d := currentDate(c)
-			if s := c.Args().First(); s != "" {
-				t, err := time.Parse("0102", s)
+			args, ignored := splitDateArgs(c.Args().Slice())
+			if len(ignored) > 0 {
+				log.Printf("flags must be placed before dates, ignored: %s", strings.Join(ignored, " "))
+			}
+			if len(args) == 0 {
+				args = []string{""}
+			}
+
+			results := []*alias.Result{}
+			for _, s := range args {
+				rs, err := h.WrapResults(getArgResult(s, d, c.Bool("reverse")))
 				if err != nil {
 					return err
 				}
-				d.Month, d.Day = int(t.Month()), t.Day()
-			}
-
-			results, err := h.WrapResults(getLunarResult(d, c.Bool("reverse")))
-			if err != nil {
-				return err
+				results = append(results, rs...)
 			}
-			outputResults(results, c)
-			return nil
+			return outputResults(results, c, nil)
 		},
 	}
 
@@ -171,73 +345,6 @@ func main() {
 	}
 }
 
-func outputResults(rs []*alias.Result, c *cli.Context) {
-	dateFormat := c.String("format")
-	sort.Slice(rs, func(i, j int) bool {
-		di, dj := rs[i].Date, rs[j].Date
-		if di.Year != dj.Year {
-			return di.Year < dj.Year
-		}
-		if di.Month != dj.Month {
-			return di.Month < dj.Month
-		}
-
-		return di.Day < dj.Day
-	})
-
-	data := make([][]string, len(rs))
-	now := currentDate(nil).Time()
-
-	for i, r := range rs {
-		// calc timedelta
-		var timedeltaStr string
-		timedelta := int(now.Sub(r.Date.Time()).Hours() / 24)
-		switch {
-		case timedelta < 0:
-			timedeltaStr = fmt.Sprintf("还有 %d 天", -timedelta)
-		case timedelta == 0:
-			timedeltaStr = "今天"
-		case timedelta > 0:
-			timedeltaStr = fmt.Sprintf("已过去 %d 天", timedelta)
-		}
-
-		leapMonthStr := ""
-		if r.LunarDate.IsLeapMonth {
-			leapMonthStr = " (闰月)"
-		}
-		row := []string{
-			r.Date.Time().Format(dateFormat),
-			r.LunarDate.Time().Format(dateFormat) + leapMonthStr,
-			r.WeekdayRaw,
-			timedeltaStr,
-			r.SolarTerm,
-		}
-
-		aliases := []string{}
-		tagMap := map[string]bool{}
-		tags := []string{}
-		for _, a := range r.Aliases {
-			aliases = append(aliases, a.Name)
-			for _, t := range a.Tags {
-				if !tagMap[t] {
-					tagMap[t] = true
-					tags = append(tags, t)
-				}
-			}
-		}
-		row = append(row, strings.Join(aliases, ","))
-		row = append(row, strings.Join(tags, ","))
-		data[i] = row
-	}
-
-	table := tablewriter.NewWriter(os.Stdout)
-	header := []string{"阳历", "阴历", "星期", "距今", "节气", "别名", "标签"}
-	table.SetHeader(header)
-	table.SetAlignment(tablewriter.ALIGN_LEFT)
-	table.AppendBulk(data)
-	table.Render()
-}
-
 func getLunarResult(d lunar.Date, reverse bool) ([]*lunar.Result, error) {
 	results := []*lunar.Result{}
 	if reverse {
@@ -271,6 +378,56 @@ func getLunarResult(d lunar.Date, reverse bool) ([]*lunar.Result, error) {
 	return results, nil
 }
 
+// getArgResult queries a positional argument, lunar dates like 正月初一 and L2024-04-20
+// are queried directly, others are parsed by parseDate
+func getArgResult(s string, d lunar.Date, reverse bool) ([]*lunar.Result, error) {
+	if s == "" {
+		return getLunarResult(d, reverse)
+	}
+
+	if ld, err := lunar.ParseLunarDate(s); err == nil {
+		if ld.Year == 0 {
+			ld.Year = d.Year
+		}
+		return getLunarDateResult(ld)
+	}
+
+	d, err := parseDate(s, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return getLunarResult(d, reverse)
+}
+
+// yearRange years of the --from and --to flags
+func yearRange(c *cli.Context) (int, int, error) {
+	from, to := c.Int("from"), c.Int("to")
+	if from == 0 {
+		from = c.Int("year")
+	}
+	if !c.IsSet("to") {
+		to = from
+	}
+	if to < from {
+		return 0, 0, fmt.Errorf("invalid year range, --to %d is before --from %d", to, from)
+	}
+
+	return from, to, nil
+}
+
+func getLunarDateResult(d lunar.LunarDate) ([]*lunar.Result, error) {
+	r, err := lunar.Calendar(d)
+	if err == lunar.ErrNotFound {
+		return []*lunar.Result{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []*lunar.Result{r}, nil
+}
+
 func currentDate(c *cli.Context) lunar.Date {
 	d := lunar.DateByTime(time.Now().In(_CST))
 	if c != nil {
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"golang.org/x/term"
+
+	"github.com/xwjdsh/lunar"
+	"github.com/xwjdsh/lunar/alias"
+)
+
+var ansiPattern = regexp.MustCompile("\033\\[[0-9;]*m")
+
+func outputMonth(h *alias.Handler, year, month int, mondayFirst bool) error {
+	rs, err := getMonthResults(h, year, month)
+	if err != nil {
+		return err
+	}
+
+	color := term.IsTerminal(int(os.Stdout.Fd()))
+	fmt.Println(yearMonthTitle(year, month))
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(weekdayHeader(mondayFirst))
+	table.SetAutoWrapText(false)
+	table.SetRowLine(true)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.AppendBulk(monthGrid(rs, mondayFirst, func(r *alias.Result) string {
+		return dayCell(r, currentDate(nil), color)
+	}))
+	table.Render()
+	return nil
+}
+
+func getMonthResults(h *alias.Handler, year, month int) ([]*alias.Result, error) {
+	first := lunar.NewDate(year, month, 1)
+	last := lunar.DateByTime(first.Time().AddDate(0, 1, -1))
+	return h.WrapResults(h.Range(first, last))
+}
+
+func weekdayHeader(mondayFirst bool) []string {
+	header := make([]string, 7)
+	for i := range header {
+		header[i] = msg().weekdays[weekdayIndex(i, mondayFirst)]
+	}
+
+	return header
+}
+
+// weekdayIndex converts a column to time.Weekday value, or the reverse when mondayFirst
+func weekdayIndex(i int, mondayFirst bool) int {
+	if mondayFirst {
+		return (i + 1) % 7
+	}
+
+	return i
+}
+
+// monthGrid lays out results of a month into weeks of 7 cells
+func monthGrid(rs []*alias.Result, mondayFirst bool, cellFunc func(*alias.Result) string) [][]string {
+	if len(rs) == 0 {
+		return nil
+	}
+
+	offset := int(rs[0].Weekday)
+	if mondayFirst {
+		offset = (offset + 6) % 7
+	}
+
+	rows := [][]string{}
+	row := make([]string, 7)
+	for i, r := range rs {
+		col := (offset + i) % 7
+		row[col] = cellFunc(r)
+		if col == 6 || i == len(rs)-1 {
+			rows = append(rows, row)
+			row = make([]string, 7)
+		}
+	}
+
+	return rows
+}
+
+// dayCell highlights today only if color is set, eg. the output is a terminal
+func dayCell(r *alias.Result, today lunar.Date, color bool) string {
+	day := strconv.Itoa(r.Date.Day)
+	if color && r.Date == today {
+		day = highlight(day)
+	}
+
+	lines := []string{day, lunarDayName(r.LunarDate)}
+	if r.SolarTerm != "" {
+		lines = append(lines, solarTermName(r.Result))
+	}
+	lines = append(lines, shownAliases(r)...)
+
+	return strings.Join(lines, "\n")
+}
+
+func highlight(s string) string {
+	return "\033[7m" + s + "\033[0m"
+}
+
+func parseYearMonth(s string, d lunar.Date) (lunar.Date, error) {
+	t, err := time.Parse("200601", s)
+	if err != nil {
+		return d, err
+	}
+	d.Year, d.Month = t.Year(), int(t.Month())
+
+	return d, nil
+}
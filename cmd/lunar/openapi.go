@@ -0,0 +1,124 @@
+package main
+
+// openAPIDocument describes the API of the serve command
+const openAPIDocument = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "lunar",
+    "description": "Conversion between Gregorian calendar and lunar calendar (1901~2100).",
+    "version": "1.0.0"
+  },
+  "paths": {
+    "/calendar": {
+      "get": {
+        "summary": "Convert a date",
+        "parameters": [
+          {
+            "name": "date",
+            "in": "query",
+            "description": "Gregorian or lunar date, eg. 2024-02-10, today, +30d, 正月初一, L2024-04-20, defaults to today",
+            "schema": {"type": "string"}
+          }
+        ],
+        "responses": {
+          "200": {"description": "The converted date", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Record"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/range": {
+      "get": {
+        "summary": "Convert dates of a range",
+        "parameters": [
+          {"name": "from", "in": "query", "required": true, "description": "Gregorian or lunar start date, inclusive", "schema": {"type": "string"}},
+          {"name": "to", "in": "query", "required": true, "description": "Gregorian or lunar end date, inclusive, at most 3660 days after from", "schema": {"type": "string"}}
+        ],
+        "responses": {
+          "200": {"$ref": "#/components/responses/Records"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/solar-terms": {
+      "get": {
+        "summary": "Get solar terms of a year",
+        "parameters": [
+          {"$ref": "#/components/parameters/Year"},
+          {
+            "name": "name",
+            "in": "query",
+            "description": "Solar term names in chinese, pinyin or english, eg. 清明, qingming, Pure Brightness",
+            "schema": {"type": "array", "items": {"type": "string"}},
+            "explode": true
+          }
+        ],
+        "responses": {
+          "200": {"$ref": "#/components/responses/Records"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/aliases": {
+      "get": {
+        "summary": "Get aliases of a year",
+        "parameters": [
+          {"$ref": "#/components/parameters/Year"},
+          {
+            "name": "name",
+            "in": "query",
+            "description": "Alias names, all aliases if neither name nor tag is set",
+            "schema": {"type": "array", "items": {"type": "string"}},
+            "explode": true
+          },
+          {"name": "tag", "in": "query", "description": "Alias tag, takes precedence over name", "schema": {"type": "string"}}
+        ],
+        "responses": {
+          "200": {"$ref": "#/components/responses/Records"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "parameters": {
+      "Year": {"name": "year", "in": "query", "description": "Target year, defaults to the current year", "schema": {"type": "integer"}}
+    },
+    "responses": {
+      "Records": {
+        "description": "The converted dates",
+        "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Record"}}}}
+      },
+      "Error": {
+        "description": "The error",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}
+      }
+    },
+    "schemas": {
+      "Record": {
+        "type": "object",
+        "required": ["date", "lunar_date", "is_leap_month", "weekday", "solar_term", "aliases", "tags", "days_from_today"],
+        "properties": {
+          "date": {"type": "string", "format": "date", "example": "2024-02-10"},
+          "lunar_date": {"type": "string", "example": "2024-01-01"},
+          "is_leap_month": {"type": "boolean"},
+          "weekday": {"type": "string", "example": "Saturday"},
+          "solar_term": {"type": "string", "example": "立春"},
+          "aliases": {"type": "array", "items": {"type": "string"}, "example": ["春节"]},
+          "tags": {"type": "array", "items": {"type": "string"}},
+          "days_from_today": {"type": "integer"},
+          "zodiac": {"type": "string", "example": "甲辰 木龙"},
+          "moment": {"type": "string", "format": "date-time", "description": "Moment of the solar term"}
+        }
+      },
+      "Error": {
+        "type": "object",
+        "required": ["error"],
+        "properties": {"error": {"type": "string"}}
+      }
+    }
+  }
+}`
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/xwjdsh/lunar"
+	"github.com/xwjdsh/lunar/alias"
+)
+
+// record fields of a result in machine-readable formats, the names and the values are stable,
+// eg. weekdays in english and solar terms in chinese whatever the --lang is
+type record struct {
+	Date          string     `json:"date" yaml:"date"`
+	LunarDate     string     `json:"lunar_date" yaml:"lunar_date"`
+	IsLeapMonth   bool       `json:"is_leap_month" yaml:"is_leap_month"`
+	Weekday       string     `json:"weekday" yaml:"weekday"`
+	SolarTerm     string     `json:"solar_term" yaml:"solar_term"`
+	Aliases       []string   `json:"aliases" yaml:"aliases"`
+	Tags          []string   `json:"tags" yaml:"tags"`
+	DaysFromToday int        `json:"days_from_today" yaml:"days_from_today"`
+	Zodiac        string     `json:"zodiac,omitempty" yaml:"zodiac,omitempty"`
+	Moment        *time.Time `json:"moment,omitempty" yaml:"moment,omitempty"`
+
+	// lunarFormatted whether the lunar date is in the --lunar-format, which shows leap months itself
+	lunarFormatted bool
+	// names in the --lang for table and markdown formats
+	weekdayName   string
+	solarTermName string
+	zodiacName    string
+	// aliasNames aliases shown in table and markdown formats, see shownAliases
+	aliasNames []string
+}
+
+var recordFields = []string{"date", "lunar_date", "is_leap_month", "weekday", "solar_term", "aliases", "tags", "days_from_today"}
+
+// recordOptions formats of record fields
+type recordOptions struct {
+	dateFormat  string
+	lunarFormat string
+	zodiac      bool
+}
+
+func recordOptionsOf(c *cli.Context) recordOptions {
+	return recordOptions{
+		dateFormat:  c.String("format"),
+		lunarFormat: c.String("lunar-format"),
+		zodiac:      c.Bool("zodiac"),
+	}
+}
+
+func newRecord(r *alias.Result, opts recordOptions, today lunar.Date) *record {
+	rec := &record{
+		Date:          r.Date.Time().Format(opts.dateFormat),
+		LunarDate:     r.LunarDate.Time().Format(opts.dateFormat),
+		IsLeapMonth:   r.LunarDate.IsLeapMonth,
+		Weekday:       r.Weekday.String(),
+		SolarTerm:     r.SolarTerm,
+		Aliases:       []string{},
+		Tags:          []string{},
+		DaysFromToday: int(r.Date.Time().Sub(today.Time()).Hours() / 24),
+		weekdayName:   lunar.WeekdayName(r.Weekday, locale),
+		solarTermName: solarTermName(r.Result),
+		aliasNames:    shownAliases(r),
+	}
+	if opts.lunarFormat != "" {
+		rec.LunarDate = r.LunarDate.FormatIn(opts.lunarFormat, locale)
+		rec.lunarFormatted = true
+	}
+
+	tagMap := map[string]bool{}
+	for _, a := range r.Aliases {
+		rec.Aliases = append(rec.Aliases, a.Name)
+		for _, t := range a.Tags {
+			if !tagMap[t] {
+				tagMap[t] = true
+				rec.Tags = append(rec.Tags, t)
+			}
+		}
+	}
+	if opts.zodiac {
+		rec.Zodiac = zodiacName(r.YearPillar, lunar.LocaleSimplified)
+		rec.zodiacName = zodiacName(r.YearPillar, locale)
+	}
+
+	return rec
+}
+
+// shownAliases names of the aliases except the ones which are the solar term of the day,
+// eg. the default 清明, it is shown as the solar term already
+func shownAliases(r *alias.Result) []string {
+	names := []string{}
+	for _, a := range r.Aliases {
+		if a.Anchor != nil && a.Anchor.OffsetDays == 0 && a.Name == r.SolarTerm {
+			continue
+		}
+		names = append(names, a.Name)
+	}
+
+	return names
+}
+
+// outputResults prints results in the format of the --output flag,
+// the moment column is shown if times is not nil
+func outputResults(rs []*alias.Result, c *cli.Context, times map[lunar.Date]time.Time) error {
+	sortResults(rs)
+
+	today, opts := currentDate(nil), recordOptionsOf(c)
+	records := make([]*record, len(rs))
+	for i, r := range rs {
+		records[i] = newRecord(r, opts, today)
+		if t, ok := times[r.Date]; ok {
+			records[i].Moment = &t
+		}
+	}
+
+	switch output := c.String("output"); output {
+	case "table", "":
+		outputTable(records, c.Bool("zodiac"), times != nil, false)
+	case "markdown", "md":
+		outputTable(records, c.Bool("zodiac"), times != nil, true)
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	case "yaml":
+		encoder := yaml.NewEncoder(os.Stdout)
+		if err := encoder.Encode(records); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case "csv", "tsv":
+		return outputCSV(records, c.Bool("zodiac"), times != nil, output == "tsv")
+	default:
+		return fmt.Errorf("unknown output format %q", output)
+	}
+
+	return nil
+}
+
+// sortResults sorts results by date
+func sortResults(rs []*alias.Result) {
+	sort.Slice(rs, func(i, j int) bool {
+		di, dj := rs[i].Date, rs[j].Date
+		if di.Year != dj.Year {
+			return di.Year < dj.Year
+		}
+		if di.Month != dj.Month {
+			return di.Month < dj.Month
+		}
+
+		return di.Day < dj.Day
+	})
+}
+
+func outputTable(records []*record, showZodiac, showMoment, markdown bool) {
+	table := tablewriter.NewWriter(os.Stdout)
+	header := append([]string{}, msg().header...)
+	if showZodiac {
+		header = append(header, msg().zodiac)
+	}
+	if showMoment {
+		header = append(header, msg().moment)
+	}
+	table.SetHeader(header)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	if markdown {
+		table.SetAutoFormatHeaders(false)
+		table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+		table.SetCenterSeparator("|")
+	}
+	table.AppendBulk(tableRows(records, showZodiac, showMoment))
+	table.Render()
+}
+
+// tableRows rows of records in table and markdown formats
+func tableRows(records []*record, showZodiac, showMoment bool) [][]string {
+	data := make([][]string, len(records))
+	for i, rec := range records {
+		lunarDate := rec.LunarDate
+		if rec.IsLeapMonth && !rec.lunarFormatted {
+			lunarDate += msg().leapMonth
+		}
+		row := []string{
+			rec.Date,
+			lunarDate,
+			rec.weekdayName,
+			daysDelta(-rec.DaysFromToday),
+			rec.solarTermName,
+			strings.Join(rec.aliasNames, ","),
+			strings.Join(rec.Tags, ","),
+		}
+		if showZodiac {
+			row = append(row, rec.zodiacName)
+		}
+		if showMoment {
+			row = append(row, formatMoment(rec.Moment))
+		}
+		data[i] = row
+	}
+
+	return data
+}
+
+func outputCSV(records []*record, showZodiac, showMoment, tsv bool) error {
+	w := csv.NewWriter(os.Stdout)
+	if tsv {
+		w.Comma = '\t'
+	}
+
+	header := append([]string{}, recordFields...)
+	if showZodiac {
+		header = append(header, "zodiac")
+	}
+	if showMoment {
+		header = append(header, "moment")
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, rec := range records {
+		row := []string{
+			rec.Date,
+			rec.LunarDate,
+			strconv.FormatBool(rec.IsLeapMonth),
+			rec.Weekday,
+			rec.SolarTerm,
+			strings.Join(rec.Aliases, ","),
+			strings.Join(rec.Tags, ","),
+			strconv.Itoa(rec.DaysFromToday),
+		}
+		if showZodiac {
+			row = append(row, rec.Zodiac)
+		}
+		if showMoment {
+			row = append(row, formatMoment(rec.Moment))
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+
+	return w.Error()
+}
+
+func formatMoment(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format("2006-01-02 15:04:05 MST")
+}
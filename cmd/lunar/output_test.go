@@ -0,0 +1,94 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xwjdsh/lunar"
+	"github.com/xwjdsh/lunar/alias"
+	"github.com/xwjdsh/lunar/config"
+)
+
+func TestTableRows(t *testing.T) {
+	lr, err := lunar.Calendar(lunar.NewDate(2020, 6, 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &alias.Result{Result: lr}
+	today := lunar.NewDate(2020, 6, 5)
+
+	for _, c := range []struct {
+		lunarFormat string
+		expected    string
+	}{
+		{"", "2020-04-14 (闰月)"},
+		{"{G}年{N}{D}", "庚子年闰四月十四"},
+	} {
+		opts := recordOptions{dateFormat: "2006-01-02", lunarFormat: c.lunarFormat}
+		rows := tableRows([]*record{newRecord(r, opts, today)}, false, false)
+		if actual := rows[0][1]; actual != c.expected {
+			t.Errorf("tableRows error, lunar format: %q, expected: %s, actual: %s", c.lunarFormat, c.expected, actual)
+		}
+	}
+}
+
+func TestNewRecord(t *testing.T) {
+	defer func(l lunar.Locale) { locale = l }(locale)
+
+	lr, err := lunar.Calendar(lunar.NewDate(2024, 4, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &alias.Result{Result: lr}
+	opts := recordOptions{dateFormat: "2006-01-02", zodiac: true}
+
+	// the values of machine-readable formats do not change with the locale
+	for _, l := range []lunar.Locale{lunar.LocaleSimplified, lunar.LocaleTraditional, lunar.LocaleEnglish} {
+		locale = l
+		rec := newRecord(r, opts, lr.Date)
+		if actual := [3]string{rec.Weekday, rec.SolarTerm, rec.Zodiac}; actual != [3]string{"Thursday", "清明", "甲辰 木龙"} {
+			t.Errorf("newRecord error, locale: %v, expected: [Thursday 清明 甲辰 木龙], actual: %v", l, actual)
+		}
+	}
+
+	rows := tableRows([]*record{newRecord(r, opts, lr.Date)}, true, false)
+	if actual := [3]string{rows[0][2], rows[0][4], rows[0][7]}; actual != [3]string{"Thursday", "Pure Brightness", "Jiachen Wood Dragon"} {
+		t.Errorf("tableRows error, expected: [Thursday Pure Brightness Jiachen Wood Dragon], actual: %v", actual)
+	}
+}
+
+// newTestHandler returns a handler with the default aliases and the aliases
+func newTestHandler(t *testing.T, as ...*config.Alias) *alias.Handler {
+	conf, err := config.Init("", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := alias.NewHandler(lunar.New())
+	if err := h.LoadAlias(append(conf.Aliases, as...)); err != nil {
+		t.Fatal(err)
+	}
+
+	return h
+}
+
+func TestShownAliases(t *testing.T) {
+	h := newTestHandler(t,
+		config.NewAnchorAlias("寒食", config.Anchor{SolarTerm: "清明", OffsetDays: -1}),
+		config.NewAnchorAlias("踏青", config.Anchor{SolarTerm: "清明"}),
+	)
+
+	for d, expected := range map[lunar.Date]string{
+		lunar.NewDate(2024, 4, 3): "寒食",
+		// the default 清明 is shown as the solar term only
+		lunar.NewDate(2024, 4, 4): "踏青",
+	} {
+		r, err := h.WrapResult(h.Calendar(d))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := strings.Join(shownAliases(r), ","); actual != expected {
+			t.Errorf("shownAliases error, date: %s, expected: %s, actual: %s", d, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/xwjdsh/lunar"
+	"github.com/xwjdsh/lunar/alias"
+)
+
+const (
+	// maxRangeDays limits days of a range query
+	maxRangeDays = 3660
+	// shutdownTimeout waits for in-flight requests on shutdown
+	shutdownTimeout = 10 * time.Second
+)
+
+// apiError an error with the HTTP status code
+type apiError struct {
+	status int
+	err    error
+}
+
+func (e *apiError) Error() string {
+	return e.err.Error()
+}
+
+func badRequest(err error) error {
+	return &apiError{status: http.StatusBadRequest, err: err}
+}
+
+// apiHandler returns the value encoded as JSON
+type apiHandler func(r *http.Request) (interface{}, error)
+
+func (fn apiHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	v, err := fn(r)
+	if err != nil {
+		status := http.StatusInternalServerError
+		var ae *apiError
+		switch {
+		case errors.As(err, &ae):
+			status = ae.status
+		case err == lunar.ErrNotFound || errors.Is(err, fs.ErrNotExist):
+			status = http.StatusNotFound
+		case err == lunar.ErrInvalidRange:
+			status = http.StatusBadRequest
+		}
+		writeJSON(w, status, map[string]string{"error": err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, v)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println(err)
+	}
+}
+
+type server struct {
+	h *alias.Handler
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/calendar", apiHandler(s.calendar))
+	mux.Handle("/range", apiHandler(s.dateRange))
+	mux.Handle("/solar-terms", apiHandler(s.solarTerms))
+	mux.Handle("/aliases", apiHandler(s.aliases))
+	mux.Handle("/openapi.json", apiHandler(func(*http.Request) (interface{}, error) {
+		return json.RawMessage(openAPIDocument), nil
+	}))
+
+	return mux
+}
+
+// serve runs the HTTP API server until SIGINT or SIGTERM, then shuts down gracefully
+func serve(h *alias.Handler, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           (&server{h: h}).routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return runServer(srv)
+}
+
+func runServer(srv *http.Server) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.ListenAndServe()
+	}()
+	log.Printf("listening on %s", srv.Addr)
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("shutting down")
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	return srv.Shutdown(ctx)
+}
+
+var apiRecordOptions = recordOptions{dateFormat: "2006-01-02", zodiac: true}
+
+func (s *server) records(rs []*alias.Result) []*record {
+	today := currentDate(nil)
+	records := make([]*record, len(rs))
+	for i, r := range rs {
+		records[i] = newRecord(r, apiRecordOptions, today)
+	}
+
+	return records
+}
+
+// parseDateParam parses lunar dates by lunar.ParseLunarDate, others by parseDate,
+// the year is the current one if omitted
+func parseDateParam(s string) (lunar.DateType, error) {
+	today := currentDate(nil)
+	if ld, err := lunar.ParseLunarDate(s); err == nil {
+		if ld.Year == 0 {
+			ld.Year = today.Year
+		}
+		return ld, nil
+	}
+
+	d, err := parseDate(s, today)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+
+	return d, nil
+}
+
+func yearParam(r *http.Request) (int, error) {
+	s := r.URL.Query().Get("year")
+	if s == "" {
+		return currentDate(nil).Year, nil
+	}
+
+	year, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, badRequest(errors.New("invalid year " + strconv.Quote(s)))
+	}
+
+	return year, nil
+}
+
+func (s *server) calendar(r *http.Request) (interface{}, error) {
+	param := r.URL.Query().Get("date")
+	if param == "" {
+		param = "today"
+	}
+	dt, err := parseDateParam(param)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.h.WrapResult(s.h.Calendar(dt))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.records([]*alias.Result{res})[0], nil
+}
+
+func (s *server) dateRange(r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	if q.Get("from") == "" || q.Get("to") == "" {
+		return nil, badRequest(errors.New("from and to are required"))
+	}
+	from, err := parseDateParam(q.Get("from"))
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDateParam(q.Get("to"))
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := s.h.Calendar(from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := s.h.Calendar(to)
+	if err != nil {
+		return nil, err
+	}
+	if end.Date.Time().Sub(start.Date.Time()) > maxRangeDays*24*time.Hour {
+		return nil, badRequest(errors.New("range exceeds " + strconv.Itoa(maxRangeDays) + " days"))
+	}
+
+	rs, err := s.h.WrapResults(s.h.Range(from, to))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.records(rs), nil
+}
+
+func (s *server) solarTerms(r *http.Request) (interface{}, error) {
+	year, err := yearParam(r)
+	if err != nil {
+		return nil, err
+	}
+
+	names := r.URL.Query()["name"]
+	for _, name := range names {
+		if _, err := lunar.ParseSolarTerm(name); err != nil {
+			return nil, badRequest(err)
+		}
+	}
+
+	events, err := s.h.GetSolarTermEvents(year, names...)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*record, len(events))
+	for i, e := range events {
+		res, _ := s.h.WrapResult(e.Result, nil)
+		records[i] = s.records([]*alias.Result{res})[0]
+		t := e.Time
+		records[i].Moment = &t
+	}
+
+	return records, nil
+}
+
+func (s *server) aliases(r *http.Request) (interface{}, error) {
+	year, err := yearParam(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var rs []*alias.Result
+	q := r.URL.Query()
+	if tag := q.Get("tag"); tag != "" {
+		rs, err = s.h.GetAliasesByTag(year, tag)
+	} else {
+		rs, err = s.h.GetAliases(year, q["name"]...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sortResults(rs)
+
+	return s.records(rs), nil
+}
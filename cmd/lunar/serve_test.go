@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	routes := (&server{h: newTestHandler(t)}).routes()
+	for _, c := range []struct {
+		method, target string
+		status         int
+		// dates of the returned records
+		dates []string
+	}{
+		{http.MethodGet, "/calendar?date=2024-02-10", http.StatusOK, []string{"2024-02-10"}},
+		{http.MethodGet, "/calendar?date=2024-01-01", http.StatusOK, []string{"2024-01-01"}},
+		{http.MethodGet, "/calendar?date=L2024-01-01", http.StatusOK, []string{"2024-02-10"}},
+		{http.MethodGet, "/calendar?date=2024-13-01", http.StatusBadRequest, nil},
+		{http.MethodGet, "/calendar?date=2101-01-01", http.StatusNotFound, nil},
+		{http.MethodPost, "/calendar?date=2024-02-10", http.StatusMethodNotAllowed, nil},
+		{http.MethodGet, "/range?from=2024-02-09&to=2024-02-11", http.StatusOK, []string{"2024-02-09", "2024-02-10", "2024-02-11"}},
+		{http.MethodGet, "/range?from=2024-02-11&to=2024-02-09", http.StatusBadRequest, nil},
+		{http.MethodGet, "/range?from=2024-02-09", http.StatusBadRequest, nil},
+		{http.MethodGet, "/range?from=2000-01-01&to=2024-01-01", http.StatusBadRequest, nil},
+		{http.MethodGet, "/range?from=2100-12-31&to=2101-01-01", http.StatusNotFound, nil},
+		{http.MethodGet, "/solar-terms?year=2024&name=qingming", http.StatusOK, []string{"2024-04-04"}},
+		{http.MethodGet, "/solar-terms?year=2024&name=qingmin", http.StatusBadRequest, nil},
+		{http.MethodGet, "/solar-terms?year=x", http.StatusBadRequest, nil},
+		{http.MethodGet, "/solar-terms?year=2100", http.StatusNotFound, nil},
+		{http.MethodGet, "/aliases?year=2024&name=春节&name=除夕", http.StatusOK, []string{"2024-02-09", "2024-02-10"}},
+		{http.MethodGet, "/aliases?year=2024&tag=none", http.StatusOK, []string{}},
+		{http.MethodGet, "/aliases?year=2101", http.StatusNotFound, nil},
+	} {
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, httptest.NewRequest(c.method, c.target, nil))
+		if w.Code != c.status {
+			t.Errorf("Server error, %s %s, expected: %d, actual: %d %s", c.method, c.target, c.status, w.Code, w.Body)
+			continue
+		}
+		if c.dates == nil {
+			var body map[string]string
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body["error"] == "" {
+				t.Errorf("Server error, %s %s, expected: error message, actual: %s", c.method, c.target, w.Body)
+			}
+			continue
+		}
+
+		var records []*record
+		if err := json.Unmarshal(w.Body.Bytes(), &records); err != nil {
+			// single record of /calendar
+			var rec record
+			if err := json.Unmarshal(w.Body.Bytes(), &rec); err != nil {
+				t.Fatal(err)
+			}
+			records = []*record{&rec}
+		}
+		actual := make([]string, len(records))
+		for i, rec := range records {
+			actual[i] = rec.Date
+		}
+		if len(actual) != len(c.dates) {
+			t.Errorf("Server error, %s %s, expected: %v, actual: %v", c.method, c.target, c.dates, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != c.dates[i] {
+				t.Errorf("Server error, %s %s, expected: %v, actual: %v", c.method, c.target, c.dates, actual)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+
+	"github.com/xwjdsh/lunar"
+	"github.com/xwjdsh/lunar/alias"
+)
+
+const (
+	yearCellWidth  = 4
+	yearMonthWidth = 7*yearCellWidth + 6
+	yearMonthGap   = 3
+	yearMaxColumns = 4
+)
+
+func outputYear(h *alias.Handler, year int, columns int, mondayFirst bool) error {
+	color := term.IsTerminal(int(os.Stdout.Fd()))
+	blocks := make([][]string, 12)
+	for i := range blocks {
+		rs, err := getMonthResults(h, year, i+1)
+		if err != nil {
+			return err
+		}
+		blocks[i] = yearMonthBlock(rs, year, i+1, mondayFirst, color)
+	}
+
+	if columns <= 0 {
+		columns = yearColumns(terminalWidth())
+	}
+
+	for i := 0; i < len(blocks); i += columns {
+		end := i + columns
+		if end > len(blocks) {
+			end = len(blocks)
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		for _, line := range joinBlocks(blocks[i:end]) {
+			fmt.Println(strings.TrimRight(line, " "))
+		}
+	}
+
+	return nil
+}
+
+// yearMonthBlock renders a month into lines of yearMonthWidth,
+// each week takes two lines, the gregorian days and the lunar days
+func yearMonthBlock(rs []*alias.Result, year, month int, mondayFirst, color bool) []string {
+	lines := []string{
+		center(yearMonthTitle(year, month), yearMonthWidth),
+		joinCells(weekdayHeader(mondayFirst)),
+	}
+
+	today := currentDate(nil)
+	terms := []string{}
+	weeks := monthGrid(rs, mondayFirst, func(r *alias.Result) string {
+		day := pad(strconv.Itoa(r.Date.Day), yearCellWidth)
+		if color && r.Date == today {
+			day = highlight(day)
+		}
+
+		mark := lunarDayName(r.LunarDate)
+		switch {
+		case r.LunarDate.Day == 1:
+			mark = shortMonthName(r.LunarDate)
+			if color {
+				mark = "\033[1m" + mark + "\033[0m"
+			}
+		case r.SolarTerm != "" && locale != lunar.LocaleEnglish:
+			mark = solarTermName(r.Result)
+			if color {
+				mark = "\033[32m" + mark + "\033[0m"
+			}
+		}
+		if r.SolarTerm != "" {
+			name := solarTermName(r.Result)
+			// english names are too long for the terms line
+			if locale == lunar.LocaleEnglish {
+				name = r.Term.Pinyin()
+			}
+			terms = append(terms, fmt.Sprintf("%s %d/%d", name, r.Date.Month, r.Date.Day))
+		}
+
+		return day + "\n" + mark
+	})
+	for _, week := range weeks {
+		days, marks := make([]string, len(week)), make([]string, len(week))
+		for i, cell := range week {
+			if parts := strings.SplitN(cell, "\n", 2); len(parts) == 2 {
+				days[i], marks[i] = parts[0], parts[1]
+			}
+		}
+		lines = append(lines, joinCells(days), joinCells(marks))
+	}
+	// a month spans at most six weeks, keep the solar terms line aligned
+	for i := len(weeks); i < 6; i++ {
+		lines = append(lines, "", "")
+	}
+
+	return append(lines, strings.Join(terms, "  "))
+}
+
+// shortMonthName two characters month name, eg. 正月, 腊月, 闰四, M4, L4
+func shortMonthName(d lunar.LunarDate) string {
+	if d.Month < 1 || d.Month > 12 {
+		return ""
+	}
+
+	m := msg()
+	name := m.shortMonths[d.Month]
+	switch {
+	case d.IsLeapMonth && locale == lunar.LocaleEnglish:
+		return m.leap + name[1:]
+	case d.IsLeapMonth:
+		return m.leap + name
+	case locale == lunar.LocaleEnglish:
+		return name
+	}
+
+	return name + "月"
+}
+
+func joinCells(cells []string) string {
+	padded := make([]string, len(cells))
+	for i, c := range cells {
+		padded[i] = pad(c, yearCellWidth)
+	}
+
+	return strings.Join(padded, " ")
+}
+
+// joinBlocks puts month blocks side by side
+func joinBlocks(blocks [][]string) []string {
+	height := 0
+	for _, b := range blocks {
+		if len(b) > height {
+			height = len(b)
+		}
+	}
+
+	lines := make([]string, height)
+	for i := range lines {
+		parts := make([]string, len(blocks))
+		for j, b := range blocks {
+			line := ""
+			if i < len(b) {
+				line = b[i]
+			}
+			parts[j] = pad(line, yearMonthWidth)
+		}
+		lines[i] = strings.Join(parts, strings.Repeat(" ", yearMonthGap))
+	}
+
+	return lines
+}
+
+// pad pads s with spaces to the display width, ANSI escape codes are ignored
+func pad(s string, width int) string {
+	if w := runewidth.StringWidth(ansiPattern.ReplaceAllString(s, "")); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+
+	return s
+}
+
+func center(s string, width int) string {
+	if w := runewidth.StringWidth(s); w < width {
+		return pad(strings.Repeat(" ", (width-w)/2)+s, width)
+	}
+
+	return s
+}
+
+// yearColumns month blocks per row which fit in the width
+func yearColumns(width int) int {
+	columns := (width + yearMonthGap) / (yearMonthWidth + yearMonthGap)
+	switch {
+	case columns < 1:
+		return 1
+	case columns > yearMaxColumns:
+		return yearMaxColumns
+	}
+
+	return columns
+}
+
+func terminalWidth() int {
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+
+	return 80
+}
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -13,7 +16,8 @@ const holidayTag = "holiday"
 type Date struct {
 	Year  int `yaml:"year"`
 	Month int `yaml:"month"`
-	Day   int `yaml:"day"`
+	// Day negative days count from the end of the lunar month, -1 is the last day
+	Day int `yaml:"day"`
 }
 
 func NewDate(y, m, d int) Date {
@@ -52,7 +56,75 @@ type Alias struct {
 	Date           Date               `yaml:"date"`
 	IsLunarDate    bool               `yaml:"is_lunar_date"`
 	LeapMonthLimit LeapMonthLimitType `yaml:"leap_month_limit"`
-	Tags           []string           `yaml:"tags"`
+	// Rule the alias recurs by the rule instead of the date if it is set
+	Rule *Rule `yaml:"rule,omitempty"`
+	// Anchor the alias recurs by the solar term instead of the date if it is set
+	Anchor *Anchor  `yaml:"anchor,omitempty"`
+	Tags   []string `yaml:"tags"`
+}
+
+// Anchor the day offset days from a solar term, eg. {solar_term: 清明, offset_days: -1},
+// the solar term is in chinese, pinyin or english
+type Anchor struct {
+	SolarTerm  string `yaml:"solar_term"`
+	OffsetDays int    `yaml:"offset_days"`
+}
+
+// Rule the nth weekday of a gregorian month, eg. {month: 5, weekday: sunday, nth: 2},
+// negative nth counts from the end of the month, -1 is the last one
+type Rule struct {
+	Month   int     `yaml:"month"`
+	Weekday Weekday `yaml:"weekday"`
+	Nth     int     `yaml:"nth"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, the weekday is required
+// since its zero value is sunday
+func (r *Rule) UnmarshalYAML(value *yaml.Node) error {
+	type rule Rule
+	if err := value.Decode((*rule)(r)); err != nil {
+		return err
+	}
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		if value.Content[i].Value == "weekday" {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("line %d: weekday of the rule is required", value.Line)
+}
+
+func (r *Rule) validate() error {
+	if r.Month < 1 || r.Month > 12 {
+		return fmt.Errorf("invalid rule month %d", r.Month)
+	}
+	if r.Nth == 0 || r.Nth < -5 || r.Nth > 5 {
+		return fmt.Errorf("invalid rule nth %d", r.Nth)
+	}
+
+	return nil
+}
+
+// Weekday weekday of a rule, in english names in yaml, eg. sunday, sun
+type Weekday time.Weekday
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (w *Weekday) UnmarshalYAML(value *yaml.Node) error {
+	s := strings.ToLower(strings.TrimSpace(value.Value))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			*w = Weekday(d)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid weekday %q", value.Value)
+}
+
+// MarshalYAML implements yaml.Marshaler
+func (w Weekday) MarshalYAML() (interface{}, error) {
+	return strings.ToLower(time.Weekday(w).String()), nil
 }
 
 // NewAlias return a new Alias instance
@@ -66,6 +138,24 @@ func NewAlias(name string, date Date, isLunarDate bool, lm LeapMonthLimitType, t
 	}
 }
 
+// NewRuleAlias return a new Alias instance recurring by the rule
+func NewRuleAlias(name string, rule Rule, tags ...string) *Alias {
+	return &Alias{
+		Name: name,
+		Rule: &rule,
+		Tags: tags,
+	}
+}
+
+// NewAnchorAlias return a new Alias instance recurring by the solar term
+func NewAnchorAlias(name string, anchor Anchor, tags ...string) *Alias {
+	return &Alias{
+		Name:   name,
+		Anchor: &anchor,
+		Tags:   tags,
+	}
+}
+
 // Init init config
 func Init(fp string, useDefault bool) (*Config, error) {
 	c := defaultConfig()
@@ -82,6 +172,16 @@ func Init(fp string, useDefault bool) (*Config, error) {
 	if err := yaml.Unmarshal(data, c); err != nil {
 		return nil, err
 	}
+	for _, a := range c.Aliases {
+		if a.Rule != nil {
+			if err := a.Rule.validate(); err != nil {
+				return nil, fmt.Errorf("alias %s: %w", a.Name, err)
+			}
+		}
+		if a.Rule == nil && a.Anchor == nil && a.Date.Day < 0 && !a.IsLunarDate {
+			return nil, fmt.Errorf("alias %s: negative days are only for lunar dates", a.Name)
+		}
+	}
 	return c, nil
 }
 
@@ -91,9 +191,11 @@ func defaultConfig() *Config {
 			NewAlias("春节", NewDate(0, 1, 1), true, LeapMonthOnlyNot, holidayTag),
 			NewAlias("元旦", NewDate(0, 1, 1), false, LeapMonthNoLimit, holidayTag),
 			NewAlias("元宵", NewDate(0, 1, 15), true, LeapMonthOnlyNot),
-			NewAlias("清明", NewDate(0, 4, 4), false, LeapMonthNoLimit, holidayTag),
+			NewAnchorAlias("清明", Anchor{SolarTerm: "清明"}, holidayTag),
 			NewAlias("劳动", NewDate(0, 5, 1), false, LeapMonthNoLimit, holidayTag),
+			NewRuleAlias("母亲节", Rule{Month: 5, Weekday: Weekday(time.Sunday), Nth: 2}),
 			NewAlias("端午", NewDate(0, 5, 5), true, LeapMonthOnlyNot, holidayTag),
+			NewRuleAlias("父亲节", Rule{Month: 6, Weekday: Weekday(time.Sunday), Nth: 3}),
 			NewAlias("七夕", NewDate(0, 7, 7), true, LeapMonthOnlyNot),
 			NewAlias("中元", NewDate(0, 7, 15), true, LeapMonthOnlyNot),
 			NewAlias("中秋", NewDate(0, 8, 15), true, LeapMonthOnlyNot, holidayTag),
@@ -101,6 +203,10 @@ func defaultConfig() *Config {
 			NewAlias("国庆", NewDate(0, 10, 1), false, LeapMonthNoLimit, holidayTag),
 			NewAlias("下元", NewDate(0, 10, 15), true, LeapMonthOnlyNot),
 			NewAlias("腊八", NewDate(0, 12, 8), true, LeapMonthOnlyNot),
+			NewAlias("小年", NewDate(0, 12, 23), true, LeapMonthOnlyNot),
+			NewAlias("南方小年", NewDate(0, 12, 24), true, LeapMonthOnlyNot),
+			// the last day of 腊月, 廿九 or 三十
+			NewAlias("除夕", NewDate(0, 12, -1), true, LeapMonthOnlyNot, holidayTag),
 		},
 	}
 }
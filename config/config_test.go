@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// initConfig writes the content to a config file and inits the config from it
+func initConfig(t *testing.T, content string) (*Config, error) {
+	fp := filepath.Join(t.TempDir(), "lunar.yml")
+	if err := os.WriteFile(fp, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return Init(fp, false)
+}
+
+func TestRule(t *testing.T) {
+	c, err := initConfig(t, `
+aliases:
+  - name: 母亲节
+    rule: {month: 5, weekday: sun, nth: 2}
+  - name: 感恩节
+    rule: {month: 11, weekday: Thursday, nth: 4}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Rule{
+		{Month: 5, Weekday: Weekday(time.Sunday), Nth: 2},
+		{Month: 11, Weekday: Weekday(time.Thursday), Nth: 4},
+	}
+	if len(c.Aliases) != len(expected) {
+		t.Fatalf("Rule error, expected: %d aliases, actual: %d aliases", len(expected), len(c.Aliases))
+	}
+	for i, a := range c.Aliases {
+		if a.Rule == nil || *a.Rule != expected[i] {
+			t.Errorf("Rule error, alias: %s, expected: %+v, actual: %+v", a.Name, expected[i], a.Rule)
+		}
+	}
+}
+
+func TestInvalidRule(t *testing.T) {
+	for _, rule := range []string{
+		"{month: 5, weekday: sun, nth: 0}",
+		"{month: 5, weekday: sun, nth: 6}",
+		"{month: 5, weekday: sun, nth: -6}",
+		"{month: 0, weekday: sun, nth: 2}",
+		"{month: 13, weekday: sun, nth: 2}",
+		"{month: 5, weekday: sunny, nth: 2}",
+		"{month: 5, nth: 2}",
+	} {
+		if _, err := initConfig(t, "aliases:\n  - name: 母亲节\n    rule: "+rule+"\n"); err == nil {
+			t.Errorf("Init error, rule: %s, expected: invalid rule error, actual: nil", rule)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package lunar
+
+import (
+	"strconv"
+	"strings"
+)
+
+// layouts of LunarDate.Format, the placeholders are
+//
+//	{Y} year in chinese digits, eg. 二〇二一
+//	{y} year in arabic digits, eg. 2021
+//	{G} sexagenary year, eg. 甲辰
+//	{Z} zodiac of the year, eg. 龙
+//	{M} month name, eg. 正月, 闰四月, 十二月
+//	{N} traditional month name, eg. 正月, 冬月, 腊月
+//	{m} month in arabic digits, leap months are not marked
+//	{D} day name, eg. 初一, 廿三
+//	{d} day in arabic digits
+const (
+	// LunarFormatChinese eg. 二〇二一年六月十一
+	LunarFormatChinese = "{Y}年{M}{D}"
+	// LunarFormatSexagenary eg. 甲辰年腊月廿三
+	LunarFormatSexagenary = "{G}年{N}{D}"
+	// LunarFormatMonthDay eg. 闰四月二十
+	LunarFormatMonthDay = "{M}{D}"
+)
+
+var (
+	chineseYearDigits = []string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+	// traditionalMonthNames names of the 11th and 12th months
+	traditionalMonthNames = map[Locale][]string{
+		LocaleSimplified:  {"冬", "腊"},
+		LocaleTraditional: {"冬", "臘"},
+	}
+)
+
+// ChineseString returns the date in chinese, eg. 二〇二一年六月十一, 二〇二〇年闰四月二十
+func (d LunarDate) ChineseString() string {
+	return d.Format(LunarFormatChinese)
+}
+
+// Format returns the date in simplified chinese by the layout, see LunarFormatChinese
+func (d LunarDate) Format(layout string) string {
+	return d.FormatIn(layout, LocaleSimplified)
+}
+
+// FormatIn returns the date in the locale by the layout, see LunarFormatChinese
+func (d LunarDate) FormatIn(layout string, l Locale) string {
+	year := yearPillar(d.Year)
+	return strings.NewReplacer(
+		"{Y}", d.yearName(l),
+		"{y}", strconv.Itoa(d.Year),
+		"{G}", year.Name(l),
+		"{Z}", year.Branch.Zodiac().Name(l),
+		"{M}", d.MonthNameIn(l),
+		"{N}", d.traditionalMonthName(l),
+		"{m}", strconv.Itoa(d.Month),
+		"{D}", d.DayNameIn(l),
+		"{d}", strconv.Itoa(d.Day),
+	).Replace(layout)
+}
+
+func (d LunarDate) yearName(l Locale) string {
+	s := strconv.Itoa(d.Year)
+	if l == LocaleEnglish {
+		return s
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteString(chineseYearDigits[r-'0'])
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// traditionalMonthName names the 11th and 12th months 冬月 and 腊月
+func (d LunarDate) traditionalMonthName(l Locale) string {
+	names, ok := traditionalMonthNames[l]
+	if !ok || d.Month < 11 || d.Month > 12 {
+		return d.MonthNameIn(l)
+	}
+
+	name := names[d.Month-11] + "月"
+	if d.IsLeapMonth {
+		return leapMonthPrefix(l) + name
+	}
+
+	return name
+}
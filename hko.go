@@ -0,0 +1,154 @@
+package lunar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"time"
+)
+
+/*
+cd ./files && curl -O https://www.hko.gov.hk/tc/gts/time/calendar/text/files/T\[1901-2100\]c.txt && \
+	find . -type f -exec sh -c 'iconv -f big5 -t utf-8 -c {} > {}.utf8' \; -exec mv "{}".utf8 "{}" \; && cd ..
+*/
+
+// hkoFirstMonths lunar month of the first day of the earliest table,
+// which can not be inferred from the previous year
+var hkoFirstMonths = map[int]int{
+	1901: 11,
+}
+
+// hkoSource reads the calendar tables published by Hong Kong Observatory
+type hkoSource struct {
+	fsys fs.FS
+}
+
+// NewHKOSource returns a DataSource which reads tables in the format of Hong Kong Observatory,
+// named like T2021c.txt and encoded in UTF-8, eg. NewHKOSource(os.DirFS("/path/to/tables"))
+func NewHKOSource(fsys fs.FS) DataSource {
+	return hkoSource{fsys: fsys}
+}
+
+func (s hkoSource) Open(year int) (DayIterator, error) {
+	f, err := s.fsys.Open(fmt.Sprintf("T%dc.txt", year))
+	if err != nil {
+		return nil, err
+	}
+
+	return &hkoReader{
+		Closer:     f,
+		r:          bufio.NewReader(f),
+		year:       year,
+		firstMonth: hkoFirstMonths[year],
+	}, nil
+}
+
+type hkoReader struct {
+	io.Closer
+	r          *bufio.Reader
+	year       int
+	firstMonth int
+}
+
+func (r *hkoReader) Next() (*Day, error) {
+	for {
+		line, err := r.r.ReadString('\n')
+		if len(line) == 0 && err != nil {
+			return nil, err
+		}
+
+		d, err := parseLine(line, r.year)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+
+		if r.firstMonth != 0 && d.LunarMonth == 0 {
+			d.LunarMonth = r.firstMonth
+		}
+		r.firstMonth = 0
+		return d, nil
+	}
+}
+
+func fileDateFormat(year int) string {
+	format := "2006年1月2日"
+	if year <= 2010 {
+		format = "2006年01月02日"
+	}
+
+	return format
+}
+
+var lunarMap = map[rune]int{
+	'天': 0,
+	'初': 0,
+	'正': 1,
+	'一': 1,
+	'二': 2,
+	'廿': 2,
+	'三': 3,
+	'四': 4,
+	'五': 5,
+	'六': 6,
+	'七': 7,
+	'八': 8,
+	'九': 9,
+	'十': 10,
+}
+
+// parseLine parses a day line of the table, returns nil if the line is not a day,
+// eg. blank lines, headers and notes
+func parseLine(line string, fileYear int) (*Day, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || !strings.HasSuffix(fields[0], "日") {
+		return nil, nil
+	}
+
+	d := &Day{}
+	rs := []rune(fields[1])
+	isMonth := false
+	if rs[len(rs)-1] == rune('月') {
+		isMonth = true
+		rs = rs[:len(rs)-1]
+	}
+
+	if isMonth && rs[0] == rune('閏') {
+		d.IsLeapMonth = true
+		rs = rs[1:]
+	}
+
+	unitDigit := lunarMap[rs[len(rs)-1]]
+	tensDigit := 0
+	if len(rs) > 1 {
+		tensDigit = lunarMap[rs[0]]
+		if tensDigit == 10 {
+			tensDigit = 1
+		}
+		if tensDigit != 0 && unitDigit == 10 {
+			tensDigit--
+		}
+	}
+
+	d.LunarDay = tensDigit*10 + unitDigit
+	if isMonth {
+		d.LunarMonth = d.LunarDay
+		d.LunarDay = 1
+	}
+
+	t, err := time.Parse(fileDateFormat(fileYear), fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("lunar: parse time error: %w", err)
+	}
+	d.Date = DateByTime(t)
+
+	if len(fields) > 3 {
+		d.SolarTerm = fields[3]
+	}
+
+	return d, nil
+}
@@ -0,0 +1,238 @@
+// Command gentable compiles the HKO tables into the compact table of package lunar.
+//
+// Lunar years which are partly outside the HKO tables, the first and the last,
+// are completed by the astronomical calendar, the boundaries are verified
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/xwjdsh/lunar"
+)
+
+const (
+	firstYear = 1901
+
+	monthSizeBits = 13
+	leapMonthBits = 4
+	termBits      = 2
+)
+
+type monthStart struct {
+	date  lunar.Date
+	day   int
+	month lunar.LunarDate
+}
+
+func main() {
+	dir := flag.String("files", "files", "directory of the HKO tables")
+	output := flag.String("o", "table_gen.go", "output file")
+	flag.Parse()
+
+	if err := run(*dir, *output); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(dir, output string) error {
+	fsys := os.DirFS(dir)
+	lastYear := firstYear
+	for {
+		if _, err := fs.Stat(fsys, fmt.Sprintf("T%dc.txt", lastYear+1)); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				break
+			}
+			return err
+		}
+		lastYear++
+	}
+
+	hko := lunar.NewWithSource(lunar.NewHKOSource(fsys))
+	astro := lunar.NewAstronomical()
+
+	var (
+		starts []monthStart
+		terms  = map[int][]lunar.Date{}
+		day    int
+	)
+	collect := func(r *lunar.Result) bool {
+		if r.LunarDate.Day == 1 {
+			starts = append(starts, monthStart{date: r.Date, day: day, month: r.LunarDate})
+		}
+		if r.SolarTerm != "" {
+			terms[r.Date.Year] = append(terms[r.Date.Year], r.Date)
+		}
+		day++
+		return true
+	}
+
+	first, last := lunar.NewDate(firstYear, 1, 1), lunar.NewDate(lastYear, 12, 31)
+	if err := verifyBoundary(hko, astro, first, last); err != nil {
+		return err
+	}
+	// lunar year firstYear-1 begins in the gregorian year firstYear-1,
+	// the lunar year lastYear ends in the gregorian year lastYear+1
+	if err := astro.RangeFunc(lunar.NewDate(firstYear-1, 1, 1), lunar.NewDate(firstYear-1, 12, 31), collect); err != nil {
+		return err
+	}
+	if err := hko.RangeFunc(first, last, collect); err != nil {
+		return err
+	}
+	if err := astro.RangeFunc(lunar.NewDate(lastYear+1, 1, 1), lunar.NewDate(lastYear+1, 12, 31), collect); err != nil {
+		return err
+	}
+
+	years, err := lunarYears(starts, firstYear-1, lastYear)
+	if err != nil {
+		return err
+	}
+	termTable, termBase, err := solarTerms(terms, firstYear, lastYear)
+	if err != nil {
+		return err
+	}
+
+	return write(output, years, termTable, termBase)
+}
+
+// verifyBoundary makes sure the astronomical calendar continues the HKO tables
+func verifyBoundary(hko, astro *lunar.Handler, dates ...lunar.Date) error {
+	for _, d := range dates {
+		expected, err := hko.Calendar(d)
+		if err != nil {
+			return err
+		}
+		actual, err := astro.Calendar(d)
+		if err != nil {
+			return err
+		}
+		if expected.LunarDate != actual.LunarDate {
+			return fmt.Errorf("lunar date of %s mismatch, hko: %v, astronomical: %v", d, expected.LunarDate, actual.LunarDate)
+		}
+	}
+
+	return nil
+}
+
+func lunarYears(starts []monthStart, from, to int) ([]uint32, error) {
+	var years []uint32
+	for i := 0; i < len(starts); i++ {
+		s := starts[i]
+		if s.month.Month != 1 || s.month.IsLeapMonth || s.month.Year < from || s.month.Year > to {
+			continue
+		}
+		if s.month.Year != from+len(years) {
+			return nil, fmt.Errorf("lunar year %d is missing", from+len(years))
+		}
+
+		var info uint32
+		j := i
+		for ; j+1 < len(starts) && starts[j+1].month.Year == s.month.Year; j++ {
+			if size := starts[j+1].day - starts[j].day; size == 30 {
+				info |= 1 << (j - i)
+			} else if size != 29 {
+				return nil, fmt.Errorf("lunar year %d has a month of %d days", s.month.Year, size)
+			}
+			if starts[j+1].month.IsLeapMonth {
+				info |= uint32(starts[j+1].month.Month) << monthSizeBits
+			}
+		}
+		if j+1 == len(starts) {
+			return nil, fmt.Errorf("lunar year %d is incomplete", s.month.Year)
+		}
+		if size := starts[j+1].day - starts[j].day; size == 30 {
+			info |= 1 << (j - i)
+		} else if size != 29 {
+			return nil, fmt.Errorf("lunar year %d has a month of %d days", s.month.Year, size)
+		}
+
+		offset := s.date.Time().Sub(lunar.NewDate(s.date.Year, 1, 1).Time()).Hours() / 24
+		if s.date.Year != s.month.Year || offset >= 1<<6 {
+			return nil, fmt.Errorf("正月初一 of lunar year %d is out of range: %s", s.month.Year, s.date)
+		}
+		years = append(years, info|uint32(offset)<<(monthSizeBits+leapMonthBits))
+	}
+	if len(years) != to-from+1 {
+		return nil, fmt.Errorf("lunar year %d is missing", from+len(years))
+	}
+
+	return years, nil
+}
+
+// solarTerms packs the days of terms, the base of a term is the earliest day of all years
+func solarTerms(terms map[int][]lunar.Date, from, to int) ([]uint64, []uint8, error) {
+	base := make([]uint8, 24)
+	for i := range base {
+		base[i] = 31
+	}
+	for y := from; y <= to; y++ {
+		ds := terms[y]
+		if len(ds) != len(base) {
+			return nil, nil, fmt.Errorf("year %d has %d solar terms", y, len(ds))
+		}
+		for i, d := range ds {
+			if d.Month != i/2+1 {
+				return nil, nil, fmt.Errorf("the solar term %d of year %d is in month %d", i, y, d.Month)
+			}
+			if uint8(d.Day) < base[i] {
+				base[i] = uint8(d.Day)
+			}
+		}
+	}
+
+	var table []uint64
+	for y := from; y <= to; y++ {
+		var bits uint64
+		for i, d := range terms[y] {
+			offset := uint64(d.Day) - uint64(base[i])
+			if offset >= 1<<termBits {
+				return nil, nil, fmt.Errorf("the solar term %d of year %d is out of range: %s", i, y, d)
+			}
+			bits |= offset << (termBits * i)
+		}
+		table = append(table, bits)
+	}
+
+	return table, base, nil
+}
+
+func write(output string, years []uint32, terms []uint64, base []uint8) error {
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, "// Code generated by go run ./internal/gentable; DO NOT EDIT.")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "package lunar")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "func init() {")
+	fmt.Fprintln(buf, "lunarYearTable = []uint32{")
+	for i, info := range years {
+		fmt.Fprintf(buf, "%#08x, // %d\n", info, firstYear-1+i)
+	}
+	fmt.Fprintln(buf, "}")
+	fmt.Fprintln(buf, "solarTermTable = []uint64{")
+	for i, bits := range terms {
+		fmt.Fprintf(buf, "%#012x, // %d\n", bits, firstYear+i)
+	}
+	fmt.Fprintln(buf, "}")
+	fmt.Fprint(buf, "solarTermBase = []uint8{")
+	for i, b := range base {
+		if i > 0 {
+			fmt.Fprint(buf, ", ")
+		}
+		fmt.Fprint(buf, b)
+	}
+	fmt.Fprintln(buf, "}")
+	fmt.Fprintln(buf, "}")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(output, src, 0o644)
+}
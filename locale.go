@@ -0,0 +1,180 @@
+package lunar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale language of names, see ParseLocale
+type Locale string
+
+const (
+	// LocaleSimplified simplified chinese, the default
+	LocaleSimplified Locale = "zh-Hans"
+	// LocaleTraditional traditional chinese
+	LocaleTraditional Locale = "zh-Hant"
+	// LocaleEnglish english, pinyin is used for names without a common translation
+	LocaleEnglish Locale = "en"
+)
+
+// ParseLocale parses language tags like zh-Hant, zh-TW, en and POSIX locales like zh_HK.UTF-8, en_US.UTF-8
+func ParseLocale(s string) (Locale, error) {
+	tag := strings.ToLower(strings.ReplaceAll(s, "_", "-"))
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	switch tag {
+	case "zh-hant", "zh-tw", "zh-hk", "zh-mo":
+		return LocaleTraditional, nil
+	case "zh", "zh-hans", "zh-cn", "zh-sg":
+		return LocaleSimplified, nil
+	}
+	switch {
+	case strings.HasPrefix(tag, "zh-hant-"):
+		return LocaleTraditional, nil
+	case strings.HasPrefix(tag, "zh-hans-"):
+		return LocaleSimplified, nil
+	case tag == "en" || strings.HasPrefix(tag, "en-"):
+		return LocaleEnglish, nil
+	}
+
+	return LocaleSimplified, fmt.Errorf("lunar: unknown locale %q", s)
+}
+
+var (
+	stemPinyinNames   = []string{"Jia", "Yi", "Bing", "Ding", "Wu", "Ji", "Geng", "Xin", "Ren", "Gui"}
+	branchPinyinNames = []string{"Zi", "Chou", "Yin", "Mao", "Chen", "Si", "Wu", "Wei", "Shen", "You", "Xu", "Hai"}
+
+	zodiacTraditionalNames = []string{"鼠", "牛", "虎", "兔", "龍", "蛇", "馬", "羊", "猴", "雞", "狗", "豬"}
+	zodiacEnglishNames     = []string{"Rat", "Ox", "Tiger", "Rabbit", "Dragon", "Snake", "Horse", "Goat", "Monkey", "Rooster", "Dog", "Pig"}
+
+	elementEnglishNames = []string{"Wood", "Fire", "Earth", "Metal", "Water"}
+)
+
+// Name returns the name in the locale, pinyin in english, eg. Jia
+func (s Stem) Name(l Locale) string {
+	if l == LocaleEnglish && s >= StemJia && s <= StemGui {
+		return stemPinyinNames[s]
+	}
+
+	return s.String()
+}
+
+// Name returns the name in the locale, pinyin in english, eg. Zi
+func (b Branch) Name(l Locale) string {
+	if l == LocaleEnglish && b >= BranchZi && b <= BranchHai {
+		return branchPinyinNames[b]
+	}
+
+	return b.String()
+}
+
+// Name returns the name in the locale, pinyin in english, eg. Jiachen
+func (s Sexagenary) Name(l Locale) string {
+	if l == LocaleEnglish {
+		return s.Stem.Name(l) + strings.ToLower(s.Branch.Name(l))
+	}
+
+	return s.String()
+}
+
+// Name returns the name in the locale
+func (z Zodiac) Name(l Locale) string {
+	if z < ZodiacRat || z > ZodiacPig {
+		return ""
+	}
+
+	switch l {
+	case LocaleTraditional:
+		return zodiacTraditionalNames[z]
+	case LocaleEnglish:
+		return zodiacEnglishNames[z]
+	}
+
+	return z.String()
+}
+
+// Name returns the name in the locale
+func (e Element) Name(l Locale) string {
+	if l == LocaleEnglish && e >= ElementWood && e <= ElementWater {
+		return elementEnglishNames[e]
+	}
+
+	return e.String()
+}
+
+// Name returns the name in the locale
+func (t SolarTerm) Name(l Locale) string {
+	switch l {
+	case LocaleTraditional:
+		return t.Traditional()
+	case LocaleEnglish:
+		return t.English()
+	}
+
+	return t.Simplified()
+}
+
+// WeekdayName returns the name of the weekday in the locale, eg. 星期一, Monday
+func WeekdayName(w time.Weekday, l Locale) string {
+	if w < time.Sunday || w > time.Saturday {
+		return ""
+	}
+	if l == LocaleEnglish {
+		return w.String()
+	}
+
+	return weekdayRawNames[w]
+}
+
+// MonthNameIn returns the name of the lunar month in the locale, eg. 閏四月, Leap Month 4
+func (d LunarDate) MonthNameIn(l Locale) string {
+	if d.Month < 1 || d.Month > 12 {
+		return ""
+	}
+
+	if l == LocaleEnglish {
+		name := "Month " + strconv.Itoa(d.Month)
+		if d.IsLeapMonth {
+			name = "Leap " + name
+		}
+		return name
+	}
+
+	name := chineseMonthOne
+	switch {
+	case d.Month > 10:
+		name = chineseDigits[10] + chineseDigits[d.Month-10]
+	case d.Month > 1:
+		name = chineseDigits[d.Month]
+	}
+	if d.IsLeapMonth {
+		name = leapMonthPrefix(l) + name
+	}
+
+	return name + "月"
+}
+
+// leapMonthPrefix prefix of chinese leap month names
+func leapMonthPrefix(l Locale) string {
+	if l == LocaleTraditional {
+		return "閏"
+	}
+
+	return "闰"
+}
+
+// DayNameIn returns the name of the lunar day in the locale, eg. 廿三, Day 23
+func (d LunarDate) DayNameIn(l Locale) string {
+	if d.Day < 1 || d.Day > 30 {
+		return ""
+	}
+	if l == LocaleEnglish {
+		return "Day " + strconv.Itoa(d.Day)
+	}
+
+	return d.DayName()
+}
@@ -0,0 +1,65 @@
+package lunar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLocale(t *testing.T) {
+	m := map[string]Locale{
+		"zh-Hans":     LocaleSimplified,
+		"zh_CN.UTF-8": LocaleSimplified,
+		"zh-Hant-HK":  LocaleTraditional,
+		"zh_TW":       LocaleTraditional,
+		"en":          LocaleEnglish,
+		"en_US.UTF-8": LocaleEnglish,
+	}
+	for k, v := range m {
+		l, err := ParseLocale(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l != v {
+			t.Errorf("ParseLocale error, expected: %s, actual: %s", v, l)
+		}
+	}
+	if _, err := ParseLocale("C"); err == nil {
+		t.Errorf("ParseLocale error, expected: error, actual: nil")
+	}
+}
+
+func TestLocaleNames(t *testing.T) {
+	d := NewLunarDate(NewDate(2020, 4, 23), true)
+	z := NewSexagenary(40)
+	m := map[Locale][]string{
+		LocaleSimplified:  {"闰四月", "廿三", "甲辰", "龙", "木", "惊蛰", "星期一"},
+		LocaleTraditional: {"閏四月", "廿三", "甲辰", "龍", "木", "驚蟄", "星期一"},
+		LocaleEnglish:     {"Leap Month 4", "Day 23", "Jiachen", "Dragon", "Wood", "Awakening of Insects", "Monday"},
+	}
+	for l, expected := range m {
+		actual := []string{
+			d.MonthNameIn(l), d.DayNameIn(l), z.Name(l), z.Branch.Zodiac().Name(l),
+			z.Stem.Element().Name(l), SolarTermJingZhe.Name(l), WeekdayName(time.Monday, l),
+		}
+		for i := range expected {
+			if actual[i] != expected[i] {
+				t.Errorf("LocaleNames error, expected: %s, actual: %s", expected[i], actual[i])
+			}
+		}
+	}
+}
+
+func TestLunarDateFormat(t *testing.T) {
+	m := [][2]string{
+		{"二〇二一年六月十一", NewLunarDate(NewDate(2021, 6, 11), false).ChineseString()},
+		{"閏四月二十", NewLunarDate(NewDate(2020, 4, 20), true).FormatIn(LunarFormatMonthDay, LocaleTraditional)},
+		{"甲辰年腊月廿三", NewLunarDate(NewDate(2024, 12, 23), false).Format(LunarFormatSexagenary)},
+		{"2024-12-23 龙", NewLunarDate(NewDate(2024, 12, 23), false).Format("{y}-{m}-{d} {Z}")},
+		{"Leap Month 4, Gengzi", NewLunarDate(NewDate(2020, 4, 20), true).FormatIn("{M}, {G}", LocaleEnglish)},
+	}
+	for _, v := range m {
+		if expected, actual := v[0], v[1]; actual != expected {
+			t.Errorf("LunarDateFormat error, expected: %s, actual: %s", expected, actual)
+		}
+	}
+}
@@ -1,31 +1,22 @@
 package lunar
 
 import (
-	"bufio"
-	"embed"
 	"errors"
-	"fmt"
 	"io"
-	"strings"
+	"io/fs"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/xwjdsh/lunar/config"
 )
 
-/*
-cd ./files && curl -O https://www.hko.gov.hk/tc/gts/time/calendar/text/files/T\[1901-2100\]c.txt && \
-	find . -type f -exec sh -c 'iconv -f big5 -t utf-8 -c {} > {}.utf8' \; -exec mv "{}".utf8 "{}" \; && cd ..
-*/
-
-//go:embed files
-var files embed.FS
-
 var (
 	// ErrNotFound date not found error
-	ErrNotFound  = errors.New("lunar: date not found")
-	loadFileFunc = func(name string) (io.ReadCloser, error) {
-		return files.Open("files/" + name)
-	}
+	ErrNotFound = errors.New("lunar: date not found")
+	// ErrInvalidRange range end before start error
+	ErrInvalidRange = errors.New("lunar: invalid date range")
 )
 
 // Result calendar query result
@@ -35,6 +26,14 @@ type Result struct {
 	Weekday    time.Weekday
 	WeekdayRaw string
 	SolarTerm  string
+	// Term the solar term of the day, NoSolarTerm if none
+	Term SolarTerm
+	// YearPillar sexagenary year, switches at 正月初一
+	YearPillar Sexagenary
+	// MonthPillar sexagenary month, switches at each 節 solar term
+	MonthPillar Sexagenary
+	// DayPillar sexagenary day
+	DayPillar Sexagenary
 }
 
 // DateType abstract date type, Date or LunarDate
@@ -71,6 +70,35 @@ func (d LunarDate) IsLunarDate() bool {
 	return true
 }
 
+var (
+	chineseDigits   = []string{"", "一", "二", "三", "四", "五", "六", "七", "八", "九", "十"}
+	chineseDayTens  = []string{"初", "十", "廿", "三"}
+	chineseMonthOne = "正"
+)
+
+// MonthName returns the chinese name of the lunar month, eg. 正月, 闰四月
+func (d LunarDate) MonthName() string {
+	return d.MonthNameIn(LocaleSimplified)
+}
+
+// DayName returns the chinese name of the lunar day, eg. 初一, 廿三
+func (d LunarDate) DayName() string {
+	if d.Day < 1 || d.Day > 30 {
+		return ""
+	}
+
+	switch d.Day {
+	case 10:
+		return "初十"
+	case 20:
+		return "二十"
+	case 30:
+		return "三十"
+	}
+
+	return chineseDayTens[d.Day/10] + chineseDigits[d.Day%10]
+}
+
 // NewDate returns a new Date
 func NewDate(y, m, d int) Date {
 	return Date{Year: y, Month: m, Day: d}
@@ -95,50 +123,301 @@ func (d Date) String() string {
 	return d.Time().Format("20060102")
 }
 
-func fileDateFormat(year int) string {
-	format := "2006年1月2日"
-	if year <= 2010 {
-		format = "2006年01月02日"
+// NthWeekday returns the nth weekday of the gregorian month, eg. the second sunday of May,
+// negative nth counts from the end of the month, -1 is the last one,
+// false if the month has no such day
+func NthWeekday(year, month int, weekday time.Weekday, nth int) (Date, bool) {
+	if month < 1 || month > 12 || nth == 0 {
+		return Date{}, false
 	}
 
-	return format
+	first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+	var day int
+	if nth > 0 {
+		day = 1 + int(weekday-first.Weekday()+7)%7 + (nth-1)*7
+	} else {
+		day = last.Day() - int(last.Weekday()-weekday+7)%7 + (nth+1)*7
+	}
+	if day < 1 || day > last.Day() {
+		return Date{}, false
+	}
+
+	return NewDate(year, month, day), true
+}
+
+var weekdayRawNames = []string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"}
+
+// Day a day provided by DataSource
+type Day struct {
+	Date Date
+	// LunarMonth is required on the first day of a lunar month only,
+	// 0 means the same lunar month as the day before
+	LunarMonth  int
+	IsLeapMonth bool
+	LunarDay    int
+	SolarTerm   string
+}
+
+// DataSource provides days of gregorian years, eg. the embedded HKO tables
+type DataSource interface {
+	// Open opens days of the year, the error should wrap fs.ErrNotExist if the year is not provided
+	Open(year int) (DayIterator, error)
 }
 
-var lunarMap = map[rune]int{
-	'天': 0,
-	'初': 0,
-	'正': 1,
-	'一': 1,
-	'二': 2,
-	'廿': 2,
-	'三': 3,
-	'四': 4,
-	'五': 5,
-	'六': 6,
-	'七': 7,
-	'八': 8,
-	'九': 9,
-	'十': 10,
+// DayIterator iterates days of a year in order
+type DayIterator interface {
+	// Next returns the next day, or io.EOF after the last one
+	Next() (*Day, error)
+	Close() error
+}
+
+// converter is implemented by sources which convert dates without iterating days
+type converter interface {
+	convert(dt DateType) (*Result, error)
+}
+
+// termSource is implemented by sources which find solar terms of gregorian years without iterating days
+type termSource interface {
+	solarTerms(year int) ([]*Result, error)
+}
+
+// monthSource is implemented by sources which know the months of lunar years
+type monthSource interface {
+	eachLunarMonth(year int, fn func(month int, isLeapMonth bool, size int) bool) error
 }
 
 var defaultHandler = New()
 
-type fileCache struct {
+type yearCache struct {
+	// used the tick of the last use, for evicting the least recently used year
+	used int64
+
 	results        []*Result
 	dateCache      map[Date]*Result
 	lunarDateCache map[LunarDate]*Result
 }
 
-// Handler handle date query logic
+func newYearCache() *yearCache {
+	return &yearCache{
+		results:        []*Result{},
+		dateCache:      map[Date]*Result{},
+		lunarDateCache: map[LunarDate]*Result{},
+	}
+}
+
+func (c *yearCache) add(r *Result) {
+	c.results = append(c.results, r)
+	c.dateCache[r.Date] = r
+	c.lunarDateCache[r.LunarDate] = r
+}
+
+// yearLoad a loading year, callers of the same year wait for done
+type yearLoad struct {
+	done  chan struct{}
+	cache *yearCache
+	err   error
+}
+
+// Handler handle date query logic, it is safe for concurrent use
 type Handler struct {
-	cacheMap map[int]*fileCache
+	// counters are accessed atomically, keep them 64-bit aligned
+	hits   uint64
+	misses uint64
+	tick   int64
+
+	source         DataSource
+	maxCachedYears int
+	preload        [][2]int
+
+	mu       sync.RWMutex
+	cacheMap map[int]*yearCache
+	loading  map[int]*yearLoad
+}
+
+// Option configures a Handler
+type Option func(*Handler)
+
+// WithPreload loads years from and to, both inclusive, when the Handler is created,
+// years which can not be loaded are skipped, it has no effect on DefaultSource,
+// which converts dates by arithmetic without the cache
+func WithPreload(from, to int) Option {
+	return func(h *Handler) {
+		h.preload = append(h.preload, [2]int{from, to})
+	}
+}
+
+// WithMaxCachedYears limits the cached years, the least recently used year is evicted first,
+// n <= 0 means no limit, it has no effect on DefaultSource, which has no cached years
+func WithMaxCachedYears(n int) Option {
+	return func(h *Handler) {
+		h.maxCachedYears = n
+	}
+}
+
+// Stats cache statistics of a Handler, conversions, ranges and solar terms answered by
+// the compact table of DefaultSource do not use the cache, so they are always zero
+type Stats struct {
+	// Hits queries served by cached years
+	Hits uint64
+	// Misses queries which loaded years from the source
+	Misses uint64
+	// LoadedYears cached years in ascending order
+	LoadedYears []int
+}
+
+// New returns a new Handler which queries the embedded HKO tables (1901~2100), see DefaultSource
+func New(opts ...Option) *Handler {
+	return NewWithSource(DefaultSource(), opts...)
+}
+
+// NewAstronomical returns a new Handler which computes the calendar by the
+// positions of the sun and the moon, see NewAstronomicalSource
+func NewAstronomical(opts ...Option) *Handler {
+	return NewWithSource(NewAstronomicalSource(), opts...)
 }
 
-// New returns a new Handler
-func New() *Handler {
-	return &Handler{
-		cacheMap: map[int]*fileCache{},
+// NewWithSource returns a new Handler which queries the DataSource
+func NewWithSource(s DataSource, opts ...Option) *Handler {
+	h := &Handler{
+		source:   s,
+		cacheMap: map[int]*yearCache{},
+		loading:  map[int]*yearLoad{},
 	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+	// preload after all options are applied, so the years are limited as well,
+	// sources converting by arithmetic never read the cached years
+	if _, ok := s.(converter); ok {
+		h.preload = nil
+	}
+	for _, r := range h.preload {
+		for y := r[0]; y <= r[1]; y++ {
+			_, _ = h.loadYear(y)
+		}
+	}
+
+	return h
+}
+
+// Stats returns the cache statistics
+func (h *Handler) Stats() Stats {
+	h.mu.RLock()
+	years := make([]int, 0, len(h.cacheMap))
+	for y := range h.cacheMap {
+		years = append(years, y)
+	}
+	h.mu.RUnlock()
+	sort.Ints(years)
+
+	return Stats{
+		Hits:        atomic.LoadUint64(&h.hits),
+		Misses:      atomic.LoadUint64(&h.misses),
+		LoadedYears: years,
+	}
+}
+
+// LunarMonthDays returns days of the lunar month, 29 or 30
+func LunarMonthDays(year, month int, isLeapMonth bool) (int, error) {
+	return defaultHandler.LunarMonthDays(year, month, isLeapMonth)
+}
+
+// LunarMonthDays returns days of the lunar month, 29 or 30,
+// ErrNotFound if the lunar year has no such month
+func (h *Handler) LunarMonthDays(year, month int, isLeapMonth bool) (int, error) {
+	days := 0
+	err := h.eachLunarMonth(year, func(m int, leap bool, size int) bool {
+		if m == month && leap == isLeapMonth {
+			days = size
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	if days == 0 {
+		return 0, ErrNotFound
+	}
+
+	return days, nil
+}
+
+// LeapMonth returns the leap month of the lunar year, 0 if there is none
+func LeapMonth(year int) (int, error) {
+	return defaultHandler.LeapMonth(year)
+}
+
+// LeapMonth returns the leap month of the lunar year, 0 if there is none
+func (h *Handler) LeapMonth(year int) (int, error) {
+	leapMonth := 0
+	err := h.eachLunarMonth(year, func(month int, isLeapMonth bool, size int) bool {
+		if isLeapMonth {
+			leapMonth = month
+			return false
+		}
+		return true
+	})
+
+	return leapMonth, err
+}
+
+// LunarYearDays returns days of the lunar year, including the leap month
+func LunarYearDays(year int) (int, error) {
+	return defaultHandler.LunarYearDays(year)
+}
+
+// LunarYearDays returns days of the lunar year, including the leap month
+func (h *Handler) LunarYearDays(year int) (int, error) {
+	total := 0
+	err := h.eachLunarMonth(year, func(month int, isLeapMonth bool, size int) bool {
+		total += size
+		return true
+	})
+
+	return total, err
+}
+
+// eachLunarMonth calls fn with months of the lunar year in order until it returns false,
+// months of sources without the month sizes are probed by Calendar
+func (h *Handler) eachLunarMonth(year int, fn func(month int, isLeapMonth bool, size int) bool) error {
+	if s, ok := h.source.(monthSource); ok {
+		return s.eachLunarMonth(year, fn)
+	}
+
+	for month := 1; month <= 12; month++ {
+		for _, isLeapMonth := range []bool{false, true} {
+			size, err := h.probeMonthDays(year, month, isLeapMonth)
+			if err == ErrNotFound && isLeapMonth {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if !fn(month, isLeapMonth, size) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+// probeMonthDays returns days of the lunar month by converting its 30th and 29th days
+func (h *Handler) probeMonthDays(year, month int, isLeapMonth bool) (int, error) {
+	for _, day := range []int{30, 29} {
+		_, err := h.Calendar(NewLunarDate(NewDate(year, month, day), isLeapMonth))
+		if err == nil {
+			return day, nil
+		}
+		if err != ErrNotFound {
+			return 0, err
+		}
+	}
+
+	return 0, ErrNotFound
 }
 
 // GetSolarTerms query date by solar terms
@@ -146,31 +425,35 @@ func GetSolarTerms(year int, names ...string) ([]*Result, error) {
 	return defaultHandler.GetSolarTerms(year, names...)
 }
 
-// GetSolarTerms query date by solar terms
+// GetSolarTerms query date by solar terms, names can be in any spelling of ParseSolarTerm
 func (h *Handler) GetSolarTerms(year int, names ...string) ([]*Result, error) {
 	if len(names) == 0 {
 		return h.getSolarTerms(year, nil)
 	}
-	nameMap := map[string]bool{}
+	termMap := map[SolarTerm]bool{}
 	for _, name := range names {
-		nameMap[name] = true
+		t, err := ParseSolarTerm(name)
+		if err != nil {
+			return nil, err
+		}
+		termMap[t] = true
 	}
 
 	return h.getSolarTerms(year, func(r *Result) bool {
-		return nameMap[r.SolarTerm]
+		return termMap[r.Term]
 	})
 }
 
 func (h *Handler) getSolarTerms(year int, filterFunc func(*Result) bool) ([]*Result, error) {
 	var results []*Result
 	for _, y := range []int{year, year + 1} {
-		_, err := h.dateToLunarDate(NewDate(y, 1, 1))
+		rs, err := h.yearSolarTerms(y)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, r := range h.cacheMap[y].lunarDateCache {
-			if r.SolarTerm != "" && r.LunarDate.Year == year {
+		for _, r := range rs {
+			if r.LunarDate.Year == year {
 				if filterFunc == nil || filterFunc(r) {
 					results = append(results, r)
 				}
@@ -181,259 +464,296 @@ func (h *Handler) getSolarTerms(year int, filterFunc func(*Result) bool) ([]*Res
 	return results, nil
 }
 
-// Calendar query date
-func Calendar(dt DateType) (*Result, error) {
-	return defaultHandler.Calendar(dt)
-}
-
-// Calendar query date
-func (h *Handler) Calendar(dt DateType) (*Result, error) {
-	var (
-		r   *Result
-		err error
-	)
-	if dt.IsLunarDate() {
-		r, err = h.lunarDateToDate(dt.(LunarDate))
-	} else {
-		r, err = h.dateToLunarDate(dt.(Date))
+// yearSolarTerms returns results of the solar terms of the gregorian year in order
+func (h *Handler) yearSolarTerms(year int) ([]*Result, error) {
+	if s, ok := h.source.(termSource); ok {
+		return s.solarTerms(year)
 	}
 
-	return r, err
-}
-
-func (h *Handler) dateToLunarDate(d Date) (*Result, error) {
-	if loaded, r, _ := h.queryCache(d.Year, d); loaded && r != nil {
-		return r, nil
+	c, err := h.loadYear(year)
+	if err != nil {
+		return nil, err
 	}
 
-	var lastResult *Result
-	if loaded, _, lr := h.queryCache(d.Year-1, d); loaded {
-		lastResult = lr
-	} else {
-		f, err := loadFileFunc(fmt.Sprintf("T%dc.txt", d.Year-1))
-		if err != nil {
-			return nil, err
-		}
-		defer f.Close()
-
-		_, lr, err := h.find(f, d, d.Year-1, NewLunarDate(NewDate(d.Year-2, 0, 0), false), false)
-		if err != nil && err != ErrNotFound {
-			return nil, err
+	var results []*Result
+	for _, r := range c.results {
+		if r.SolarTerm != "" {
+			results = append(results, r)
 		}
-		lastResult = lr
 	}
 
-	f, err := loadFileFunc(fmt.Sprintf("T%dc.txt", d.Year))
+	return results, nil
+}
+
+// Range query all days between from and to, both inclusive
+func Range(from, to DateType) ([]*Result, error) {
+	return defaultHandler.Range(from, to)
+}
+
+// Range query all days between from and to, both inclusive
+func (h *Handler) Range(from, to DateType) ([]*Result, error) {
+	var results []*Result
+	err := h.RangeFunc(from, to, func(r *Result) bool {
+		results = append(results, r)
+		return true
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
 
-	r, _, err := h.find(f, d, d.Year, lastResult.LunarDate, true)
-	return r, err
+	return results, nil
 }
 
-func (h *Handler) lunarDateToDate(d LunarDate) (*Result, error) {
-	var lastResult *Result
-	if fileLoaded, r, lr := h.queryCache(d.Year, d); fileLoaded {
-		if r != nil {
-			return r, nil
-		}
-		lastResult = lr
-	} else {
-		f, err := loadFileFunc(fmt.Sprintf("T%dc.txt", d.Year))
-		if err != nil {
-			return nil, err
-		}
-		defer f.Close()
+// RangeFunc calls fn for each day between from and to in order, both inclusive,
+// iteration stops when fn returns false
+func (h *Handler) RangeFunc(from, to DateType, fn func(*Result) bool) error {
+	start, err := h.Calendar(from)
+	if err != nil {
+		return err
+	}
+	end, err := h.Calendar(to)
+	if err != nil {
+		return err
+	}
 
-		r, lr, err := h.find(f, d, d.Year, NewLunarDate(NewDate(d.Year-1, 0, 0), false), false)
-		if err == nil {
-			return r, nil
-		}
-		if err != ErrNotFound {
-			return nil, err
-		}
-		lastResult = lr
+	startTime, endTime := start.Date.Time(), end.Date.Time()
+	if endTime.Before(startTime) {
+		return ErrInvalidRange
 	}
 
-	fileLoaded, r, _ := h.queryCache(d.Year+1, d)
-	if fileLoaded && r != nil {
-		return r, nil
+	// sources converting by arithmetic need no cached years
+	if c, ok := h.source.(converter); ok {
+		for t := startTime; !t.After(endTime); t = t.AddDate(0, 0, 1) {
+			r, err := c.convert(DateByTime(t))
+			if err != nil {
+				return err
+			}
+			if !fn(r) {
+				return nil
+			}
+		}
+		return nil
 	}
 
-	if !fileLoaded {
-		f1, err := loadFileFunc(fmt.Sprintf("T%dc.txt", d.Year+1))
+	for y := start.Date.Year; y <= end.Date.Year; y++ {
+		c, err := h.loadYear(y)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		defer f1.Close()
 
-		r, _, err := h.find(f1, d, d.Year+1, lastResult.LunarDate, true)
-		return r, err
+		for _, r := range c.results {
+			t := r.Date.Time()
+			if t.Before(startTime) {
+				continue
+			}
+			if t.After(endTime) || !fn(r) {
+				return nil
+			}
+		}
 	}
 
-	return nil, ErrNotFound
+	return nil
 }
 
-func (h *Handler) find(rd io.Reader, dt DateType, fileYear int, lastLunarDate LunarDate, saveCache bool) (*Result, *Result, error) {
-	r, err := prepareReader(rd)
-	if err != nil {
-		return nil, nil, err
+// Calendar query date
+func Calendar(dt DateType) (*Result, error) {
+	return defaultHandler.Calendar(dt)
+}
+
+// Calendar query date
+func (h *Handler) Calendar(dt DateType) (*Result, error) {
+	if c, ok := h.source.(converter); ok {
+		return c.convert(dt)
 	}
 
-	isLunarDate := dt.IsLunarDate()
-	lunarYear, lunarMonth := lastLunarDate.Year, lastLunarDate.Month
-	isLeapMonth := lastLunarDate.IsLeapMonth
+	if dt.IsLunarDate() {
+		return h.lunarDateToDate(dt.(LunarDate))
+	}
 
-	var (
-		result     *Result
-		lastResult *Result
-	)
-	for {
-		line, err := r.ReadString('\n')
-		if len(line) == 0 && err != nil {
-			if err == io.EOF {
-				break
-			}
-			return nil, nil, err
-		}
+	return h.dateToLunarDate(dt.(Date))
+}
 
-		res, err := h.parseLine(line, fileYear, lunarYear, lunarMonth, isLeapMonth)
-		if res == nil && err == nil {
-			continue
-		}
+func (h *Handler) cachedYear(year int) (*yearCache, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 
-		if err != nil {
-			return nil, nil, err
-		}
+	c, ok := h.cacheMap[year]
+	return c, ok
+}
 
-		if saveCache {
-			h.cache(res, fileYear)
-		}
+// use marks the cached year as the most recently used
+func (h *Handler) use(c *yearCache) *yearCache {
+	atomic.StoreInt64(&c.used, atomic.AddInt64(&h.tick, 1))
+	atomic.AddUint64(&h.hits, 1)
+	return c
+}
 
-		lastResult = res
-		isLeapMonth = res.LunarDate.IsLeapMonth
-		lunarYear, lunarMonth = res.LunarDate.Year, res.LunarDate.Month
-		if (!isLunarDate && res.Date == dt.(Date)) ||
-			(isLunarDate && res.LunarDate == dt.(LunarDate)) {
-			result = res
-		}
+// loadYear makes sure all days of the year are cached,
+// concurrent calls of the same year share one loading
+func (h *Handler) loadYear(year int) (*yearCache, error) {
+	if c, ok := h.cachedYear(year); ok {
+		return h.use(c), nil
 	}
 
-	if result == nil {
-		return nil, lastResult, ErrNotFound
+	h.mu.Lock()
+	if c, ok := h.cacheMap[year]; ok {
+		h.mu.Unlock()
+		return h.use(c), nil
 	}
+	atomic.AddUint64(&h.misses, 1)
+	if l, ok := h.loading[year]; ok {
+		h.mu.Unlock()
+		<-l.done
+		return l.cache, l.err
+	}
+	l := &yearLoad{done: make(chan struct{})}
+	h.loading[year] = l
+	h.mu.Unlock()
 
-	return result, lastResult, nil
-}
+	l.cache, l.err = h.readYear(year)
 
-func (h *Handler) parseLine(line string, fileYear int, lunarYear, lunarMonth int, isLeapMonth bool) (*Result, error) {
-	fields := strings.Fields(line)
-	if len(fields) == 0 {
-		return nil, nil
+	h.mu.Lock()
+	if l.err == nil {
+		l.cache.used = atomic.AddInt64(&h.tick, 1)
+		h.cacheMap[year] = l.cache
+		h.evict()
 	}
+	delete(h.loading, year)
+	h.mu.Unlock()
+	close(l.done)
 
-	rs := []rune(fields[1])
-	isMonth := false
-	if rs[len(rs)-1] == rune('月') {
-		isMonth = true
-		rs = rs[:len(rs)-1]
-	}
+	return l.cache, l.err
+}
 
-	if isMonth {
-		isLeapMonth = false
-		if rs[0] == rune('閏') {
-			isLeapMonth = true
-			rs = rs[1:]
+// evict removes the least recently used years beyond maxCachedYears, h.mu must be held
+func (h *Handler) evict() {
+	for h.maxCachedYears > 0 && len(h.cacheMap) > h.maxCachedYears {
+		year, used := 0, int64(-1)
+		for y, c := range h.cacheMap {
+			if u := atomic.LoadInt64(&c.used); used < 0 || u < used {
+				year, used = y, u
+			}
 		}
+		delete(h.cacheMap, year)
 	}
+}
 
-	lastChar := rs[len(rs)-1]
-	unitDigit := lunarMap[lastChar]
-	if lastChar == '正' {
-		lunarYear++
+func (h *Handler) readYear(year int) (*yearCache, error) {
+	last, err := h.lastLunarDate(year - 1)
+	if err != nil {
+		return nil, err
 	}
 
-	tensDigit := 0
-	if len(rs) > 1 {
-		tensDigit = lunarMap[rs[0]]
-		if tensDigit == 10 {
-			tensDigit = 1
-		}
-		if tensDigit != 0 && unitDigit == 10 {
-			tensDigit--
-		}
+	c := newYearCache()
+	if err := h.walk(year, last, c.add); err != nil {
+		return nil, err
 	}
 
-	lunarDay := tensDigit*10 + unitDigit
-	if isMonth {
-		lunarMonth = lunarDay
-		lunarDay = 1
-	}
+	return c, nil
+}
 
-	t, err := time.Parse(fileDateFormat(fileYear), fields[0])
-	if err != nil {
-		return nil, fmt.Errorf("lunar: parse time error: %w", err)
+// lastLunarDate returns the lunar date of the last day of the year,
+// the lunar month is unknown if the year is not provided by the source
+func (h *Handler) lastLunarDate(year int) (LunarDate, error) {
+	if c, ok := h.cachedYear(year); ok {
+		return c.results[len(c.results)-1].LunarDate, nil
 	}
 
-	weekday := []rune(fields[2])
-	r := &Result{
-		Date:       DateByTime(t),
-		LunarDate:  NewLunarDate(NewDate(lunarYear, lunarMonth, lunarDay), isLeapMonth),
-		WeekdayRaw: fields[2],
-		Weekday:    time.Weekday(lunarMap[weekday[len(weekday)-1]]),
-	}
-	if len(fields) > 3 {
-		r.SolarTerm = fields[3]
+	// days before 正月初一 belong to the previous lunar year
+	last := NewLunarDate(NewDate(year-1, 0, 0), false)
+	err := h.walk(year, last, func(r *Result) {
+		last = r.LunarDate
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewLunarDate(NewDate(year, 0, 0), false), nil
 	}
 
-	return r, nil
+	return last, err
 }
 
-func (h *Handler) cache(r *Result, fileYear int) {
-	c, ok := h.cacheMap[fileYear]
-	if !ok {
-		c = &fileCache{
-			results:        []*Result{},
-			dateCache:      map[Date]*Result{},
-			lunarDateCache: map[LunarDate]*Result{},
-		}
-		h.cacheMap[fileYear] = c
+func (h *Handler) dateToLunarDate(d Date) (*Result, error) {
+	c, err := h.loadYear(d.Year)
+	if err != nil {
+		return nil, err
 	}
 
-	c.results = append(c.results, r)
-	c.dateCache[r.Date] = r
-	c.lunarDateCache[r.LunarDate] = r
+	if r, ok := c.dateCache[d]; ok {
+		return r, nil
+	}
+
+	return nil, ErrNotFound
 }
 
-func (h *Handler) queryCache(fileYear int, dt DateType) (bool, *Result, *Result) {
-	isLunarDate := dt.IsLunarDate()
-	c, loaded := h.cacheMap[fileYear]
-	if !loaded {
-		return false, nil, nil
+func (h *Handler) lunarDateToDate(d LunarDate) (*Result, error) {
+	years := []int{d.Year}
+	// the 11th and 12th lunar months may extend to the next year
+	if d.Month >= 11 {
+		years = append(years, d.Year+1)
 	}
 
-	var r *Result
-	if isLunarDate {
-		r = c.lunarDateCache[dt.(LunarDate)]
-	} else {
-		r = c.dateCache[dt.(Date)]
+	for _, y := range years {
+		c, err := h.loadYear(y)
+		if err != nil {
+			return nil, err
+		}
+
+		if r, ok := c.lunarDateCache[d]; ok {
+			return r, nil
+		}
 	}
 
-	return true, r, c.results[len(c.results)-1]
+	return nil, ErrNotFound
 }
 
-func prepareReader(rd io.Reader) (*bufio.Reader, error) {
-	r := bufio.NewReader(rd)
+// walk reads days of the year in order, last is the lunar date of the day before the year begins
+func (h *Handler) walk(year int, last LunarDate, fn func(*Result)) error {
+	rd, err := h.source.Open(year)
+	if err != nil {
+		return err
+	}
+	defer rd.Close()
+
+	lunarYear, lunarMonth, isLeapMonth := last.Year, last.Month, last.IsLeapMonth
+	monthOrdinal := firstMonthOrdinal(year)
+	for {
+		d, err := rd.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
 
-	// skip first three lines
-	for i := 0; i < 3; i++ {
-		line, err := r.ReadString('\n')
-		if len(line) == 0 && err != nil {
-			return nil, err
+		if d.LunarMonth != 0 {
+			if d.LunarMonth == 1 && d.LunarDay == 1 && !d.IsLeapMonth {
+				lunarYear++
+			}
+			lunarMonth, isLeapMonth = d.LunarMonth, d.IsLeapMonth
+		}
+		if solarTermOf(d.SolarTerm).IsJie() {
+			monthOrdinal++
+		}
+		// the lunar month of days before the first month of the source is unknown,
+		// eg. the previous year is not provided, they are not found
+		if lunarMonth == 0 {
+			continue
 		}
+
+		fn(newResult(d, NewLunarDate(NewDate(lunarYear, lunarMonth, d.LunarDay), isLeapMonth), monthOrdinal))
 	}
+}
 
-	return r, nil
+func newResult(d *Day, ld LunarDate, monthOrdinal int) *Result {
+	weekday := d.Date.Time().Weekday()
+	return &Result{
+		Date:        d.Date,
+		LunarDate:   ld,
+		Weekday:     weekday,
+		WeekdayRaw:  weekdayRawNames[weekday],
+		SolarTerm:   d.SolarTerm,
+		Term:        solarTermOf(d.SolarTerm),
+		YearPillar:  yearPillar(ld.Year),
+		MonthPillar: monthPillar(monthOrdinal),
+		DayPillar:   dayPillar(d.Date),
+	}
 }
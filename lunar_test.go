@@ -1,7 +1,14 @@
 package lunar
 
 import (
+	"errors"
+	"io/fs"
+	"os"
+	"reflect"
+	"sync"
 	"testing"
+	"testing/fstest"
+	"time"
 )
 
 var m = map[Date]LunarDate{
@@ -38,3 +45,258 @@ func TestCalendar(t *testing.T) {
 		}
 	}
 }
+
+func TestPillars(t *testing.T) {
+	cases := []struct {
+		date             Date
+		year, month, day string
+	}{
+		{NewDate(2021, 7, 20), "辛丑", "乙未", "己巳"},
+		{NewDate(2024, 2, 3), "癸卯", "乙丑", "丁酉"},
+		{NewDate(2024, 2, 10), "甲辰", "丙寅", "甲辰"},
+	}
+	for _, c := range cases {
+		r, err := Calendar(c.date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := [3]string{r.YearPillar.String(), r.MonthPillar.String(), r.DayPillar.String()}; actual != [3]string{c.year, c.month, c.day} {
+			t.Errorf("Pillars error, date: %s, expected: %s%s%s, actual: %v", c.date, c.year, c.month, c.day, actual)
+		}
+	}
+}
+
+func TestGetZodiac(t *testing.T) {
+	cases := []struct {
+		dt       DateType
+		boundary YearBoundary
+		expected string
+	}{
+		{NewDate(2024, 2, 3), BoundaryLunarNewYear, "癸卯兔水"},
+		{NewDate(2024, 2, 5), BoundaryLunarNewYear, "癸卯兔水"},
+		{NewDate(2024, 2, 5), BoundaryLiChun, "甲辰龙木"},
+		{NewLunarDate(NewDate(2024, 1, 1), false), BoundaryLiChun, "甲辰龙木"},
+		{NewLunarDate(NewDate(2023, 12, 24), false), BoundaryLiChun, "癸卯兔水"},
+	}
+	for _, c := range cases {
+		z, err := GetZodiac(c.dt, c.boundary)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := z.Year.String() + z.Zodiac.String() + z.Element.String(); actual != c.expected {
+			t.Errorf("GetZodiac error, date: %v, expected: %s, actual: %s", c.dt, c.expected, actual)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	rs, err := Range(NewDate(2020, 12, 30), NewLunarDate(NewDate(2020, 11, 20), false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs) != 5 {
+		t.Fatalf("Range error, expected: 5 days, actual: %d days", len(rs))
+	}
+	for i, r := range rs {
+		if expected := NewDate(2020, 12, 30+i).Time(); !r.Date.Time().Equal(expected) {
+			t.Errorf("Range error, expected: %s, actual: %s", DateByTime(expected), r.Date)
+		}
+	}
+
+	if _, err := Range(NewDate(2021, 1, 2), NewDate(2021, 1, 1)); err != ErrInvalidRange {
+		t.Errorf("Range error, expected: %v, actual: %v", ErrInvalidRange, err)
+	}
+}
+
+func TestNthWeekday(t *testing.T) {
+	for _, c := range []struct {
+		year, month int
+		weekday     time.Weekday
+		nth         int
+		expected    Date
+		ok          bool
+	}{
+		{2024, 5, time.Sunday, 2, NewDate(2024, 5, 12), true},
+		{2024, 6, time.Sunday, 3, NewDate(2024, 6, 16), true},
+		{2024, 11, time.Thursday, 4, NewDate(2024, 11, 28), true},
+		{2024, 5, time.Monday, -1, NewDate(2024, 5, 27), true},
+		{2024, 2, time.Thursday, 5, NewDate(2024, 2, 29), true},
+		{2024, 2, time.Thursday, -5, NewDate(2024, 2, 1), true},
+		{2024, 3, time.Sunday, -1, NewDate(2024, 3, 31), true},
+		{2023, 2, time.Thursday, 5, Date{}, false},
+		{2024, 5, time.Sunday, 0, Date{}, false},
+		{2024, 13, time.Sunday, 1, Date{}, false},
+	} {
+		d, ok := NthWeekday(c.year, c.month, c.weekday, c.nth)
+		if d != c.expected || ok != c.ok {
+			t.Errorf("NthWeekday error, expected: %s %v, actual: %s %v", c.expected, c.ok, d, ok)
+		}
+	}
+}
+
+func TestLunarMonth(t *testing.T) {
+	for _, c := range []struct {
+		year, leapMonth, yearDays int
+	}{
+		{2020, 4, 384},
+		{2023, 2, 384},
+		{2024, 0, 354},
+		{2100, 0, 354},
+	} {
+		if actual, err := LeapMonth(c.year); err != nil || actual != c.leapMonth {
+			t.Errorf("LeapMonth error, year: %d, expected: %d, actual: %d, %v", c.year, c.leapMonth, actual, err)
+		}
+		if actual, err := LunarYearDays(c.year); err != nil || actual != c.yearDays {
+			t.Errorf("LunarYearDays error, year: %d, expected: %d, actual: %d, %v", c.year, c.yearDays, actual, err)
+		}
+	}
+
+	for _, c := range []struct {
+		year, month int
+		isLeapMonth bool
+		expected    int
+		err         error
+	}{
+		{2020, 4, false, 30, nil},
+		{2020, 4, true, 29, nil},
+		{2023, 2, true, 29, nil},
+		{2024, 1, false, 29, nil},
+		{2024, 12, false, 29, nil},
+		{2024, 4, true, 0, ErrNotFound},
+		// 腊月 of 2100 ends in 2101, out of the gregorian years of the table
+		{2100, 11, false, 30, nil},
+		{2100, 12, false, 29, nil},
+	} {
+		if actual, err := LunarMonthDays(c.year, c.month, c.isLeapMonth); actual != c.expected || err != c.err {
+			t.Errorf("LunarMonthDays error, month: %d-%d %v, expected: %d %v, actual: %d %v", c.year, c.month, c.isLeapMonth, c.expected, c.err, actual, err)
+		}
+	}
+
+	// sources without the month sizes are probed
+	h := NewWithSource(NewHKOSource(os.DirFS("files")))
+	if actual, err := h.LeapMonth(2020); err != nil || actual != 4 {
+		t.Errorf("LeapMonth error, expected: 4, actual: %d, %v", actual, err)
+	}
+	if actual, err := h.LunarYearDays(2023); err != nil || actual != 384 {
+		t.Errorf("LunarYearDays error, expected: 384, actual: %d, %v", actual, err)
+	}
+}
+
+func TestNewWithSource(t *testing.T) {
+	h := NewWithSource(NewHKOSource(os.DirFS("files")))
+	for k, v := range m {
+		d, err := h.Calendar(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := d.LunarDate; actual != v {
+			t.Errorf("NewWithSource error, expected: %s, actual: %s", v, actual)
+		}
+	}
+
+	if _, err := h.Calendar(NewDate(2101, 1, 1)); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("NewWithSource error, expected: %v, actual: %v", fs.ErrNotExist, err)
+	}
+
+	// without the previous year, the lunar month of days before 腊月 of 2021 is unknown
+	data, err := os.ReadFile("files/T2021c.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h = NewWithSource(NewHKOSource(fstest.MapFS{"T2021c.txt": {Data: data}}))
+	if _, err := h.Calendar(NewDate(2021, 1, 1)); err != ErrNotFound {
+		t.Errorf("NewWithSource error, expected: %v, actual: %v", ErrNotFound, err)
+	}
+	for k, v := range map[Date]LunarDate{
+		NewDate(2021, 1, 13): NewLunarDate(NewDate(2020, 12, 1), false),
+		NewDate(2021, 2, 12): NewLunarDate(NewDate(2021, 1, 1), false),
+		NewDate(2021, 7, 20): NewLunarDate(NewDate(2021, 6, 11), false),
+	} {
+		d, err := h.Calendar(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := d.LunarDate; actual != v {
+			t.Errorf("NewWithSource error, expected: %s, actual: %s", v, actual)
+		}
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	expected := map[int]int{}
+	for year := 2000; year < 2008; year++ {
+		rs, err := New().GetSolarTerms(year)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected[year] = len(rs)
+	}
+
+	handlers := map[string]*Handler{
+		"default":      defaultHandler,
+		"hko":          NewWithSource(NewHKOSource(os.DirFS("files"))),
+		"astronomical": NewAstronomical(),
+	}
+	for name, h := range handlers {
+		wg := sync.WaitGroup{}
+		for i := 0; i < 32; i++ {
+			wg.Add(1)
+			go func(name string, h *Handler, year int) {
+				defer wg.Done()
+				for k, v := range m {
+					d, err := h.Calendar(k)
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					if actual := d.LunarDate; actual != v {
+						t.Errorf("Concurrency error, %s handler, expected: %s, actual: %s", name, v, actual)
+					}
+				}
+
+				rs, err := h.GetSolarTerms(year)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if len(rs) != expected[year] {
+					t.Errorf("Concurrency error, %s handler, expected: %d solar terms, actual: %d", name, expected[year], len(rs))
+				}
+			}(name, h, 2000+i%8)
+		}
+		wg.Wait()
+	}
+}
+
+func TestHandlerOptions(t *testing.T) {
+	h := NewWithSource(NewHKOSource(os.DirFS("files")), WithPreload(2000, 2003), WithMaxCachedYears(2))
+	if actual, expected := h.Stats(), (Stats{Misses: 4, LoadedYears: []int{2002, 2003}}); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("HandlerOptions error, expected: %+v, actual: %+v", expected, actual)
+	}
+
+	if _, err := h.Calendar(NewDate(2002, 1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Calendar(NewDate(2010, 1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	// 2003 is the least recently used year
+	if actual, expected := h.Stats(), (Stats{Hits: 1, Misses: 5, LoadedYears: []int{2002, 2010}}); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("HandlerOptions error, expected: %+v, actual: %+v", expected, actual)
+	}
+
+	// the compact table of the default source does not use the cache
+	h = New(WithPreload(2000, 2003), WithMaxCachedYears(2))
+	if _, err := h.Calendar(NewDate(2002, 1, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Range(NewDate(2002, 1, 1), NewDate(2010, 12, 31)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.GetSolarTerms(2002); err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := h.Stats(), (Stats{LoadedYears: []int{}}); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("HandlerOptions error, expected: %+v, actual: %+v", expected, actual)
+	}
+}
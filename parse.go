@@ -0,0 +1,104 @@
+package lunar
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// eg. L2024-04-20, 2024-閏04-20, L04-20
+	numericLunarPattern = regexp.MustCompile(`^([Ll])?(?:(\d{1,4})[-/])?(闰|閏)?(\d{1,2})[-/](\d{1,2})$`)
+	// eg. 正月初一, 闰四月二十, 2024年腊月廿三, 农历二〇二四年十月初十
+	chineseLunarPattern = regexp.MustCompile(`^(?:农历|農曆)?(?:([0-9〇零一二三四五六七八九]{1,4})年)?(闰|閏)?([^月]+)月(.+)$`)
+
+	lunarMonthNames = map[string]int{"正": 1, "冬": 11, "腊": 12, "臘": 12}
+	lunarDayNames   = map[string]int{"卅": 30}
+)
+
+func init() {
+	for i := 1; i <= 12; i++ {
+		name := NewLunarDate(NewDate(0, i, 1), false).MonthName()
+		lunarMonthNames[strings.TrimSuffix(name, "月")] = i
+	}
+	lunarMonthNames["一"] = 1
+	for i := 1; i <= 30; i++ {
+		lunarDayNames[NewLunarDate(NewDate(0, 1, i), false).DayName()] = i
+		if i > 20 && i < 30 {
+			lunarDayNames["二十"+chineseDigits[i-20]] = i
+		}
+	}
+}
+
+// ParseLunarDate parses lunar dates written like 正月初一, 闰四月二十, 2024年腊月廿三,
+// L2024-04-20 and 2024-閏04-20, the year is 0 if it is omitted
+func ParseLunarDate(s string) (LunarDate, error) {
+	s = strings.TrimSpace(s)
+	if m := numericLunarPattern.FindStringSubmatch(s); m != nil {
+		// without the leading L or the leap month mark it is a gregorian date
+		if m[1] == "" && m[3] == "" {
+			return LunarDate{}, fmt.Errorf("lunar: invalid lunar date %q", s)
+		}
+		year, _ := strconv.Atoi(m[2])
+		month, _ := strconv.Atoi(m[4])
+		day, _ := strconv.Atoi(m[5])
+		return newParsedLunarDate(s, year, month, day, m[3] != "")
+	}
+
+	if m := chineseLunarPattern.FindStringSubmatch(s); m != nil {
+		year, err := parseChineseYear(m[1])
+		if err != nil {
+			return LunarDate{}, fmt.Errorf("lunar: invalid lunar date %q", s)
+		}
+		month, ok := lunarMonthNames[m[3]]
+		if !ok {
+			return LunarDate{}, fmt.Errorf("lunar: invalid lunar month %q", s)
+		}
+		day, ok := lunarDayNames[m[4]]
+		if !ok {
+			return LunarDate{}, fmt.Errorf("lunar: invalid lunar day %q", s)
+		}
+		return newParsedLunarDate(s, year, month, day, m[2] != "")
+	}
+
+	return LunarDate{}, fmt.Errorf("lunar: invalid lunar date %q", s)
+}
+
+func newParsedLunarDate(s string, year, month, day int, isLeapMonth bool) (LunarDate, error) {
+	if month < 1 || month > 12 || day < 1 || day > 30 {
+		return LunarDate{}, fmt.Errorf("lunar: invalid lunar date %q", s)
+	}
+
+	return NewLunarDate(NewDate(year, month, day), isLeapMonth), nil
+}
+
+// parseChineseYear parses years in arabic or chinese digits, eg. 2024, 二〇二四
+func parseChineseYear(s string) (int, error) {
+	year := 0
+	for _, r := range s {
+		digit := strings.IndexRune("0123456789", r)
+		if digit < 0 {
+			digit = indexOf(chineseYearDigits, string(r))
+		}
+		if r == '零' {
+			digit = 0
+		}
+		if digit < 0 {
+			return 0, fmt.Errorf("lunar: invalid year %q", s)
+		}
+		year = year*10 + digit
+	}
+
+	return year, nil
+}
+
+func indexOf(ss []string, s string) int {
+	for i, v := range ss {
+		if v == s {
+			return i
+		}
+	}
+
+	return -1
+}
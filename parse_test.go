@@ -0,0 +1,36 @@
+package lunar
+
+import (
+	"testing"
+)
+
+func TestParseLunarDate(t *testing.T) {
+	m := map[string]LunarDate{
+		"正月初一":         NewLunarDate(NewDate(0, 1, 1), false),
+		"闰四月二十":        NewLunarDate(NewDate(0, 4, 20), true),
+		"閏四月二十":        NewLunarDate(NewDate(0, 4, 20), true),
+		"2024年腊月廿三":    NewLunarDate(NewDate(2024, 12, 23), false),
+		"二〇二四年冬月三十":    NewLunarDate(NewDate(2024, 11, 30), false),
+		"农历2023年闰二月初十": NewLunarDate(NewDate(2023, 2, 10), true),
+		"L2024-04-20":  NewLunarDate(NewDate(2024, 4, 20), false),
+		"l04-20":       NewLunarDate(NewDate(0, 4, 20), false),
+		"2024-閏04-20":  NewLunarDate(NewDate(2024, 4, 20), true),
+		"L2020-闰04-01": NewLunarDate(NewDate(2020, 4, 1), true),
+	}
+	for k, v := range m {
+		actual, err := ParseLunarDate(k)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if actual != v {
+			t.Errorf("ParseLunarDate error, expected: %s %v, actual: %s %v", v, v.IsLeapMonth, actual, actual.IsLeapMonth)
+		}
+	}
+
+	for _, s := range []string{"2024-04-20", "0420", "十三月初一", "正月三十一", "L2024-13-01", "hello"} {
+		if _, err := ParseLunarDate(s); err == nil {
+			t.Errorf("ParseLunarDate error, expected: error for %s, actual: nil", s)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package lunar
+
+import "time"
+
+// Stem heavenly stem (天干)
+type Stem int
+
+const (
+	// StemJia 甲
+	StemJia Stem = iota
+	// StemYi 乙
+	StemYi
+	// StemBing 丙
+	StemBing
+	// StemDing 丁
+	StemDing
+	// StemWu 戊
+	StemWu
+	// StemJi 己
+	StemJi
+	// StemGeng 庚
+	StemGeng
+	// StemXin 辛
+	StemXin
+	// StemRen 壬
+	StemRen
+	// StemGui 癸
+	StemGui
+)
+
+var stemNames = []string{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"}
+
+func (s Stem) String() string {
+	if s < StemJia || s > StemGui {
+		return ""
+	}
+	return stemNames[s]
+}
+
+// Branch earthly branch (地支)
+type Branch int
+
+const (
+	// BranchZi 子
+	BranchZi Branch = iota
+	// BranchChou 丑
+	BranchChou
+	// BranchYin 寅
+	BranchYin
+	// BranchMao 卯
+	BranchMao
+	// BranchChen 辰
+	BranchChen
+	// BranchSi 巳
+	BranchSi
+	// BranchWu 午
+	BranchWu
+	// BranchWei 未
+	BranchWei
+	// BranchShen 申
+	BranchShen
+	// BranchYou 酉
+	BranchYou
+	// BranchXu 戌
+	BranchXu
+	// BranchHai 亥
+	BranchHai
+)
+
+var branchNames = []string{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"}
+
+func (b Branch) String() string {
+	if b < BranchZi || b > BranchHai {
+		return ""
+	}
+	return branchNames[b]
+}
+
+// Sexagenary stem-branch pair of the sexagenary cycle (干支)
+type Sexagenary struct {
+	Stem   Stem
+	Branch Branch
+}
+
+// NewSexagenary returns the Sexagenary at position i of the cycle, 甲子 is 0
+func NewSexagenary(i int) Sexagenary {
+	i = (i%60 + 60) % 60
+	return Sexagenary{Stem: Stem(i % 10), Branch: Branch(i % 12)}
+}
+
+// Index position in the sexagenary cycle, 甲子 is 0
+func (s Sexagenary) Index() int {
+	return ((6*int(s.Stem)-5*int(s.Branch))%60 + 60) % 60
+}
+
+func (s Sexagenary) String() string {
+	return s.Stem.String() + s.Branch.String()
+}
+
+// yearPillar 1984 is 甲子
+func yearPillar(lunarYear int) Sexagenary {
+	return NewSexagenary(lunarYear - 1984)
+}
+
+// monthPillar the ordinal counts solar months since 寅 month of year 0,
+// 寅 month of 甲 and 己 years is 丙寅
+func monthPillar(ordinal int) Sexagenary {
+	return NewSexagenary(ordinal + 14)
+}
+
+// firstMonthOrdinal Jan 1 always falls in the 子 month of the previous solar year
+func firstMonthOrdinal(year int) int {
+	return (year-1)*12 + 10
+}
+
+// dayPillar 1970-01-01 is 辛巳
+func dayPillar(d Date) Sexagenary {
+	return NewSexagenary(int(d.Time().Unix()/int64(24*time.Hour/time.Second)) + 17)
+}
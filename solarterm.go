@@ -0,0 +1,241 @@
+package lunar
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// SolarTerm one of the 24 solar terms (节气), starting from 小寒
+type SolarTerm int
+
+const (
+	// SolarTermXiaoHan 小寒
+	SolarTermXiaoHan SolarTerm = iota
+	// SolarTermDaHan 大寒
+	SolarTermDaHan
+	// SolarTermLiChun 立春
+	SolarTermLiChun
+	// SolarTermYuShui 雨水
+	SolarTermYuShui
+	// SolarTermJingZhe 惊蛰
+	SolarTermJingZhe
+	// SolarTermChunFen 春分
+	SolarTermChunFen
+	// SolarTermQingMing 清明
+	SolarTermQingMing
+	// SolarTermGuYu 谷雨
+	SolarTermGuYu
+	// SolarTermLiXia 立夏
+	SolarTermLiXia
+	// SolarTermXiaoMan 小满
+	SolarTermXiaoMan
+	// SolarTermMangZhong 芒种
+	SolarTermMangZhong
+	// SolarTermXiaZhi 夏至
+	SolarTermXiaZhi
+	// SolarTermXiaoShu 小暑
+	SolarTermXiaoShu
+	// SolarTermDaShu 大暑
+	SolarTermDaShu
+	// SolarTermLiQiu 立秋
+	SolarTermLiQiu
+	// SolarTermChuShu 处暑
+	SolarTermChuShu
+	// SolarTermBaiLu 白露
+	SolarTermBaiLu
+	// SolarTermQiuFen 秋分
+	SolarTermQiuFen
+	// SolarTermHanLu 寒露
+	SolarTermHanLu
+	// SolarTermShuangJiang 霜降
+	SolarTermShuangJiang
+	// SolarTermLiDong 立冬
+	SolarTermLiDong
+	// SolarTermXiaoXue 小雪
+	SolarTermXiaoXue
+	// SolarTermDaXue 大雪
+	SolarTermDaXue
+	// SolarTermDongZhi 冬至
+	SolarTermDongZhi
+)
+
+// NoSolarTerm the day has no solar term
+const NoSolarTerm SolarTerm = -1
+
+var (
+	// solarTermNames traditional names as in the HKO tables
+	solarTermNames = []string{
+		"小寒", "大寒", "立春", "雨水", "驚蟄", "春分", "清明", "穀雨", "立夏", "小滿", "芒種", "夏至",
+		"小暑", "大暑", "立秋", "處暑", "白露", "秋分", "寒露", "霜降", "立冬", "小雪", "大雪", "冬至",
+	}
+	solarTermSimplifiedNames = []string{
+		"小寒", "大寒", "立春", "雨水", "惊蛰", "春分", "清明", "谷雨", "立夏", "小满", "芒种", "夏至",
+		"小暑", "大暑", "立秋", "处暑", "白露", "秋分", "寒露", "霜降", "立冬", "小雪", "大雪", "冬至",
+	}
+	solarTermPinyinNames = []string{
+		"Xiaohan", "Dahan", "Lichun", "Yushui", "Jingzhe", "Chunfen",
+		"Qingming", "Guyu", "Lixia", "Xiaoman", "Mangzhong", "Xiazhi",
+		"Xiaoshu", "Dashu", "Liqiu", "Chushu", "Bailu", "Qiufen",
+		"Hanlu", "Shuangjiang", "Lidong", "Xiaoxue", "Daxue", "Dongzhi",
+	}
+	solarTermEnglishNames = []string{
+		"Minor Cold", "Major Cold", "Start of Spring", "Rain Water", "Awakening of Insects", "Spring Equinox",
+		"Pure Brightness", "Grain Rain", "Start of Summer", "Grain Buds", "Grain in Ear", "Summer Solstice",
+		"Minor Heat", "Major Heat", "Start of Autumn", "End of Heat", "White Dew", "Autumn Equinox",
+		"Cold Dew", "Frost's Descent", "Start of Winter", "Minor Snow", "Major Snow", "Winter Solstice",
+	}
+)
+
+// solarTermSpellings maps all spellings of solar terms, see normalizeSolarTerm
+var solarTermSpellings = map[string]SolarTerm{}
+
+func init() {
+	for i := range solarTermNames {
+		t := SolarTerm(i)
+		for _, name := range []string{t.Traditional(), t.Simplified(), t.Pinyin(), t.English()} {
+			solarTermSpellings[normalizeSolarTerm(name)] = t
+		}
+	}
+}
+
+// normalizeSolarTerm ignores case, spaces, hyphens and apostrophes, eg. "Frost's Descent" is "frostsdescent"
+func normalizeSolarTerm(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_', '\'', '’':
+			return -1
+		}
+		return r
+	}, strings.ToLower(s))
+}
+
+// ParseSolarTerm parses the name of a solar term in traditional or simplified chinese, pinyin or english,
+// eg. 惊蛰, 驚蟄, jingzhe, Awakening of Insects
+func ParseSolarTerm(s string) (SolarTerm, error) {
+	if t, ok := solarTermSpellings[normalizeSolarTerm(s)]; ok {
+		return t, nil
+	}
+
+	return NoSolarTerm, fmt.Errorf("lunar: unknown solar term %q", s)
+}
+
+// solarTermOf returns NoSolarTerm if the name is empty or unknown
+func solarTermOf(name string) SolarTerm {
+	if name == "" {
+		return NoSolarTerm
+	}
+	t, err := ParseSolarTerm(name)
+	if err != nil {
+		return NoSolarTerm
+	}
+
+	return t
+}
+
+// Valid reports whether t is one of the 24 solar terms
+func (t SolarTerm) Valid() bool {
+	return t >= SolarTermXiaoHan && t <= SolarTermDongZhi
+}
+
+func (t SolarTerm) String() string {
+	return t.Traditional()
+}
+
+// Traditional returns the name in traditional chinese, as in the HKO tables
+func (t SolarTerm) Traditional() string {
+	if !t.Valid() {
+		return ""
+	}
+	return solarTermNames[t]
+}
+
+// Simplified returns the name in simplified chinese
+func (t SolarTerm) Simplified() string {
+	if !t.Valid() {
+		return ""
+	}
+	return solarTermSimplifiedNames[t]
+}
+
+// Pinyin returns the name in pinyin without tones, eg. Qingming
+func (t SolarTerm) Pinyin() string {
+	if !t.Valid() {
+		return ""
+	}
+	return solarTermPinyinNames[t]
+}
+
+// English returns the english name, eg. Pure Brightness
+func (t SolarTerm) English() string {
+	if !t.Valid() {
+		return ""
+	}
+	return solarTermEnglishNames[t]
+}
+
+// Longitude returns the apparent solar longitude in degrees, eg. 0 for 春分
+func (t SolarTerm) Longitude() float64 {
+	return math.Mod(285+15*float64(t), 360)
+}
+
+// IsJie reports whether t starts a sexagenary month (节), eg. 立春
+func (t SolarTerm) IsJie() bool {
+	return t.Valid() && t%2 == 0
+}
+
+// IsZhongQi reports whether t is a principal term (中气), eg. 雨水
+func (t SolarTerm) IsZhongQi() bool {
+	return t.Valid() && t%2 == 1
+}
+
+// SolarTermEvent the exact moment of a solar term
+type SolarTermEvent struct {
+	Name string
+	Term SolarTerm
+	// Time the moment in UTC computed by the positions of the sun, use Time.In to render it in other zones
+	Time time.Time
+	// Result the day of the solar term
+	Result *Result
+}
+
+// GetSolarTermEvents query the exact moments of solar terms, see GetSolarTerms
+func GetSolarTermEvents(year int, names ...string) ([]*SolarTermEvent, error) {
+	return defaultHandler.GetSolarTermEvents(year, names...)
+}
+
+// GetSolarTermEvents query the exact moments of solar terms, see GetSolarTerms
+func (h *Handler) GetSolarTermEvents(year int, names ...string) ([]*SolarTermEvent, error) {
+	rs, err := h.GetSolarTerms(year, names...)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*SolarTermEvent, 0, len(rs))
+	for _, r := range rs {
+		if !r.Term.Valid() {
+			continue
+		}
+		events = append(events, &SolarTermEvent{
+			Name:   r.SolarTerm,
+			Term:   r.Term,
+			Time:   solarTermTime(r.Date.Year, r.Term),
+			Result: r,
+		})
+	}
+
+	return events, nil
+}
+
+// Time returns the moment of the solar term in the gregorian year, see SolarTermEvent.Time
+func (t SolarTerm) Time(year int) time.Time {
+	return solarTermTime(year, t)
+}
+
+// solarTermTime the moment of the solar term of the gregorian year
+func solarTermTime(year int, t SolarTerm) time.Time {
+	jde := solarTermJDE(year, t.Longitude())
+	seconds := (jde-unixEpochDay+0.5)*secondsOfDay - deltaT(jde)
+	return time.Unix(int64(math.Round(seconds)), 0).UTC()
+}
@@ -0,0 +1,42 @@
+package lunar
+
+import (
+	"testing"
+)
+
+func TestParseSolarTerm(t *testing.T) {
+	for _, s := range []string{"驚蟄", "惊蛰", "Jingzhe", "jing zhe", "Awakening of Insects", "awakening-of-insects"} {
+		term, err := ParseSolarTerm(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if term != SolarTermJingZhe {
+			t.Errorf("ParseSolarTerm error, expected: %s, actual: %s", SolarTermJingZhe, term)
+		}
+	}
+	if _, err := ParseSolarTerm("春节"); err == nil {
+		t.Errorf("ParseSolarTerm error, expected: error, actual: nil")
+	}
+
+	if term := SolarTermChunFen; term.Longitude() != 0 || !term.IsZhongQi() || term.IsJie() {
+		t.Errorf("SolarTerm error, %s longitude: %v, 中气: %v", term, term.Longitude(), term.IsZhongQi())
+	}
+	if term := SolarTermLiChun; term.Longitude() != 315 || !term.IsJie() || term.Simplified() != "立春" {
+		t.Errorf("SolarTerm error, %s longitude: %v, 节: %v", term, term.Longitude(), term.IsJie())
+	}
+}
+
+func TestGetSolarTermsSpelling(t *testing.T) {
+	for _, s := range []string{"穀雨", "谷雨", "guyu", "Grain Rain"} {
+		rs, err := GetSolarTerms(2021, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rs) != 1 || rs[0].Term != SolarTermGuYu || rs[0].Date != NewDate(2021, 4, 20) {
+			t.Errorf("GetSolarTerms error, expected: %s %s, actual: %v", NewDate(2021, 4, 20), SolarTermGuYu, rs)
+		}
+	}
+	if _, err := GetSolarTerms(2021, "unknown"); err == nil {
+		t.Errorf("GetSolarTerms error, expected: error, actual: nil")
+	}
+}
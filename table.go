@@ -0,0 +1,257 @@
+package lunar
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+//go:generate go run ./internal/gentable -files files -o table_gen.go
+
+const (
+	monthSizeBits = 13
+	leapMonthBits = 4
+	termBits      = 2
+
+	// tableFirstYear the first gregorian year of the table
+	tableFirstYear = 1901
+)
+
+// the tables are assigned in table_gen.go
+var (
+	// lunarYearTable packs lunar years from tableFirstYear-1 into bits, the lowest 13 bits
+	// are the sizes of its months in order, 1 means 30 days and 0 means 29 days,
+	// the next 4 bits are the leap month, 0 if none,
+	// the next 6 bits are the days from January 1 to 正月初一
+	lunarYearTable []uint32
+	// solarTermTable packs the days of the 24 solar terms of gregorian years from tableFirstYear
+	// into 2 bits each, starting from 小寒 in the lowest bits, the day of a term is the offset
+	// added to solarTermBase, the 1st and 2nd terms are in January, and so on
+	solarTermTable []uint64
+	solarTermBase  []uint8
+)
+
+// tableLastYear the last gregorian year of the table
+func tableLastYear() int {
+	return tableFirstYear + len(solarTermTable) - 1
+}
+
+// tableSource the compact table compiled from the HKO tables by go generate,
+// it converts dates by arithmetic instead of iterating days
+type tableSource struct{}
+
+// DefaultSource returns the DataSource of the HKO tables (1901~2100) embedded as a compact table
+func DefaultSource() DataSource {
+	return tableSource{}
+}
+
+func (s tableSource) Open(year int) (DayIterator, error) {
+	if year < tableFirstYear || year > tableLastYear() {
+		return nil, errTableYear(year)
+	}
+
+	return &tableIterator{
+		source: s,
+		day:    dayNumber(NewDate(year, 1, 1)),
+		end:    dayNumber(NewDate(year+1, 1, 1)),
+	}, nil
+}
+
+type tableIterator struct {
+	source tableSource
+	day    int
+	end    int
+}
+
+func (it *tableIterator) Next() (*Day, error) {
+	if it.day >= it.end {
+		return nil, io.EOF
+	}
+
+	d := dateOfDayNumber(it.day)
+	ld, err := it.source.lunarDate(d)
+	if err != nil {
+		return nil, err
+	}
+	term, _ := solarTermOfDate(d)
+	it.day++
+
+	return &Day{
+		Date:        d,
+		LunarMonth:  ld.Month,
+		IsLeapMonth: ld.IsLeapMonth,
+		LunarDay:    ld.Day,
+		SolarTerm:   term,
+	}, nil
+}
+
+func (it *tableIterator) Close() error {
+	return nil
+}
+
+func (s tableSource) convert(dt DateType) (*Result, error) {
+	var (
+		d   Date
+		ld  LunarDate
+		err error
+	)
+	if dt.IsLunarDate() {
+		ld = dt.(LunarDate)
+		d, err = s.date(ld)
+	} else {
+		d = dt.(Date)
+		ld, err = s.lunarDate(d)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	term, ordinal := solarTermOfDate(d)
+	return newResult(&Day{Date: d, SolarTerm: term}, ld, ordinal), nil
+}
+
+// lunarDate converts the date by walking at most 13 months from 正月初一
+func (s tableSource) lunarDate(d Date) (LunarDate, error) {
+	if d.Year < tableFirstYear || d.Year > tableLastYear() {
+		return LunarDate{}, errTableYear(d.Year)
+	}
+
+	if DateByTime(d.Time()) != d {
+		return LunarDate{}, ErrNotFound
+	}
+
+	n := dayNumber(d)
+	year := d.Year
+	if n < newYearDayNumber(year) {
+		year--
+	}
+
+	offset := n - newYearDayNumber(year)
+	var result LunarDate
+	eachLunarMonth(year, func(month int, isLeapMonth bool, size int) bool {
+		if offset < size {
+			result = NewLunarDate(NewDate(year, month, offset+1), isLeapMonth)
+			return false
+		}
+		offset -= size
+		return true
+	})
+
+	return result, nil
+}
+
+// date converts the lunar date by walking at most 13 months from 正月初一
+func (s tableSource) date(ld LunarDate) (Date, error) {
+	if ld.Year < tableFirstYear-1 || ld.Year > tableLastYear() {
+		return Date{}, errTableYear(ld.Year)
+	}
+
+	offset, found := 0, false
+	eachLunarMonth(ld.Year, func(month int, isLeapMonth bool, size int) bool {
+		if month == ld.Month && isLeapMonth == ld.IsLeapMonth {
+			found = ld.Day >= 1 && ld.Day <= size
+			offset += ld.Day - 1
+			return false
+		}
+		offset += size
+		return true
+	})
+	if !found {
+		return Date{}, ErrNotFound
+	}
+
+	d := dateOfDayNumber(newYearDayNumber(ld.Year) + offset)
+	if d.Year < tableFirstYear || d.Year > tableLastYear() {
+		return Date{}, errTableYear(d.Year)
+	}
+
+	return d, nil
+}
+
+// eachLunarMonth calls fn with months of the lunar year in order until it returns false
+func (s tableSource) eachLunarMonth(year int, fn func(month int, isLeapMonth bool, size int) bool) error {
+	if year < tableFirstYear-1 || year > tableLastYear() {
+		return errTableYear(year)
+	}
+
+	eachLunarMonth(year, fn)
+	return nil
+}
+
+// solarTerms converts the dates of the solar terms of the gregorian year in order
+func (s tableSource) solarTerms(year int) ([]*Result, error) {
+	if year < tableFirstYear || year > tableLastYear() {
+		return nil, errTableYear(year)
+	}
+
+	results := make([]*Result, 0, len(solarTermNames))
+	for i := range solarTermNames {
+		r, err := s.convert(NewDate(year, i/2+1, tableSolarTermDay(year, i)))
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+
+	return results, nil
+}
+
+func newYearDayNumber(year int) int {
+	offset := int(lunarYearTable[year-tableFirstYear+1] >> (monthSizeBits + leapMonthBits))
+	return dayNumber(NewDate(year, 1, 1)) + offset
+}
+
+// eachLunarMonth calls fn with months of the lunar year in order until it returns false
+func eachLunarMonth(year int, fn func(month int, isLeapMonth bool, size int) bool) {
+	info := lunarYearTable[year-tableFirstYear+1]
+	leapMonth := int(info>>monthSizeBits) & (1<<leapMonthBits - 1)
+
+	i := 0
+	next := func(month int, isLeapMonth bool) bool {
+		size := 29 + int(info>>i)&1
+		i++
+		return fn(month, isLeapMonth, size)
+	}
+	for month := 1; month <= 12; month++ {
+		if !next(month, false) {
+			return
+		}
+		if month == leapMonth && !next(month, true) {
+			return
+		}
+	}
+}
+
+// solarTermOfDate returns the solar term of the date if any,
+// and the ordinal of the sexagenary month, see monthPillar
+func solarTermOfDate(d Date) (string, int) {
+	var (
+		term    string
+		ordinal = firstMonthOrdinal(d.Year)
+	)
+	for i := range solarTermNames {
+		month, day := i/2+1, tableSolarTermDay(d.Year, i)
+		if month > d.Month || (month == d.Month && day > d.Day) {
+			break
+		}
+
+		if SolarTerm(i).IsJie() {
+			ordinal++
+		}
+		if month == d.Month && day == d.Day {
+			term = solarTermNames[i]
+		}
+	}
+
+	return term, ordinal
+}
+
+// tableSolarTermDay returns the day of the ith solar term of the gregorian year, it is in the month i/2+1
+func tableSolarTermDay(year, i int) int {
+	bits := solarTermTable[year-tableFirstYear]
+	return int(solarTermBase[i]) + int(bits>>(termBits*i))&(1<<termBits-1)
+}
+
+func errTableYear(year int) error {
+	return fmt.Errorf("lunar: year %d is out of the table: %w", year, fs.ErrNotExist)
+}
@@ -0,0 +1,412 @@
+// Code generated by go run ./internal/gentable; DO NOT EDIT.
+
+package lunar
+
+func init() {
+	lunarYearTable = []uint32{
+		0x003d16d2, // 1900
+		0x00620752, // 1901
+		0x004c0ea5, // 1902
+		0x0038b64a, // 1903
+		0x005c064b, // 1904
+		0x00440a9b, // 1905
+		0x00309556, // 1906
+		0x0056056a, // 1907
+		0x00400b59, // 1908
+		0x002a5752, // 1909
+		0x00500752, // 1910
+		0x003adb25, // 1911
+		0x00600b25, // 1912
+		0x00480a4b, // 1913
+		0x0032b4ab, // 1914
+		0x005802ad, // 1915
+		0x0042056b, // 1916
+		0x002c4b69, // 1917
+		0x00520da9, // 1918
+		0x003efd92, // 1919
+		0x00640e92, // 1920
+		0x004c0d25, // 1921
+		0x0036ba4d, // 1922
+		0x005c0a56, // 1923
+		0x004602b6, // 1924
+		0x002e95b5, // 1925
+		0x005606d4, // 1926
+		0x00400ea9, // 1927
+		0x002c5e92, // 1928
+		0x00500e92, // 1929
+		0x003acd26, // 1930
+		0x005e052b, // 1931
+		0x00480a57, // 1932
+		0x0032b2b6, // 1933
+		0x00580b5a, // 1934
+		0x004406d4, // 1935
+		0x002e6ec9, // 1936
+		0x00520749, // 1937
+		0x003cf693, // 1938
+		0x00620a93, // 1939
+		0x004c052b, // 1940
+		0x0034ca5b, // 1941
+		0x005a0aad, // 1942
+		0x0046056a, // 1943
+		0x00309b55, // 1944
+		0x00560ba4, // 1945
+		0x00400b49, // 1946
+		0x002a5a93, // 1947
+		0x00500a95, // 1948
+		0x0038f52d, // 1949
+		0x005e0536, // 1950
+		0x00480aad, // 1951
+		0x0034b5aa, // 1952
+		0x005805b2, // 1953
+		0x00420da5, // 1954
+		0x002e7d4a, // 1955
+		0x00540d4a, // 1956
+		0x003d0a95, // 1957
+		0x00600a97, // 1958
+		0x004c0556, // 1959
+		0x0036cab5, // 1960
+		0x005a0ad5, // 1961
+		0x004606d2, // 1962
+		0x00308ea5, // 1963
+		0x00560ea5, // 1964
+		0x0040064a, // 1965
+		0x00286c97, // 1966
+		0x004e0a9b, // 1967
+		0x003af55a, // 1968
+		0x005e056a, // 1969
+		0x00480b69, // 1970
+		0x0034b752, // 1971
+		0x005a0b52, // 1972
+		0x00420b25, // 1973
+		0x002c964b, // 1974
+		0x00520a4b, // 1975
+		0x003d14ab, // 1976
+		0x006002ad, // 1977
+		0x004a056d, // 1978
+		0x0036cb69, // 1979
+		0x005c0da9, // 1980
+		0x00460d92, // 1981
+		0x00309d25, // 1982
+		0x00560d25, // 1983
+		0x00415a4d, // 1984
+		0x00640a56, // 1985
+		0x004e02b6, // 1986
+		0x0038c5b5, // 1987
+		0x005e06d5, // 1988
+		0x00480ea9, // 1989
+		0x0034be92, // 1990
+		0x005a0e92, // 1991
+		0x00440d26, // 1992
+		0x002c6a56, // 1993
+		0x00500a57, // 1994
+		0x003d14d6, // 1995
+		0x0062035a, // 1996
+		0x004a06d5, // 1997
+		0x0036b6c9, // 1998
+		0x005c0749, // 1999
+		0x00460693, // 2000
+		0x002e952b, // 2001
+		0x0054052b, // 2002
+		0x003e0a5b, // 2003
+		0x002a555a, // 2004
+		0x004e056a, // 2005
+		0x0038fb55, // 2006
+		0x00600ba4, // 2007
+		0x004a0b49, // 2008
+		0x0032ba93, // 2009
+		0x00580a95, // 2010
+		0x0042052d, // 2011
+		0x002c8aad, // 2012
+		0x00500ab5, // 2013
+		0x003d35aa, // 2014
+		0x006205d2, // 2015
+		0x004c0da5, // 2016
+		0x0036dd4a, // 2017
+		0x005c0d4a, // 2018
+		0x00460c95, // 2019
+		0x0030952e, // 2020
+		0x00540556, // 2021
+		0x003e0ab5, // 2022
+		0x002a55b2, // 2023
+		0x005006d2, // 2024
+		0x0038cea5, // 2025
+		0x005e0725, // 2026
+		0x0048064b, // 2027
+		0x0032ac97, // 2028
+		0x00560cab, // 2029
+		0x0042055a, // 2030
+		0x002c6ad6, // 2031
+		0x00520b69, // 2032
+		0x003d7752, // 2033
+		0x00620b52, // 2034
+		0x004c0b25, // 2035
+		0x0036da4b, // 2036
+		0x005a0a4b, // 2037
+		0x004404ab, // 2038
+		0x002ea55b, // 2039
+		0x005405ad, // 2040
+		0x003e0b6a, // 2041
+		0x002a5b52, // 2042
+		0x00500d92, // 2043
+		0x003afd25, // 2044
+		0x005e0d25, // 2045
+		0x00480a55, // 2046
+		0x0032b4ad, // 2047
+		0x005804b6, // 2048
+		0x004005b5, // 2049
+		0x002c6daa, // 2050
+		0x00520ec9, // 2051
+		0x003f1e92, // 2052
+		0x00620e92, // 2053
+		0x004c0d26, // 2054
+		0x0036ca56, // 2055
+		0x005a0a57, // 2056
+		0x00440556, // 2057
+		0x002e86d5, // 2058
+		0x00540755, // 2059
+		0x00400749, // 2060
+		0x00286e93, // 2061
+		0x004e0693, // 2062
+		0x0038f52b, // 2063
+		0x005e052b, // 2064
+		0x00460a5b, // 2065
+		0x0032b55a, // 2066
+		0x0058056a, // 2067
+		0x00420b65, // 2068
+		0x002c974a, // 2069
+		0x00520b4a, // 2070
+		0x003d1a95, // 2071
+		0x00620a95, // 2072
+		0x004a052d, // 2073
+		0x0034caad, // 2074
+		0x005a0ab5, // 2075
+		0x004605aa, // 2076
+		0x002e8ba5, // 2077
+		0x00540da5, // 2078
+		0x00400d4a, // 2079
+		0x002a7c95, // 2080
+		0x004e0c96, // 2081
+		0x0038f94e, // 2082
+		0x005e0556, // 2083
+		0x00480ab5, // 2084
+		0x0032b5b2, // 2085
+		0x005806d2, // 2086
+		0x00420ea5, // 2087
+		0x002e8e4a, // 2088
+		0x0050068b, // 2089
+		0x003b0c97, // 2090
+		0x006004ab, // 2091
+		0x004a055b, // 2092
+		0x0034cad6, // 2093
+		0x005a0b6a, // 2094
+		0x00460752, // 2095
+		0x00309725, // 2096
+		0x00540b45, // 2097
+		0x003e0a8b, // 2098
+		0x0028549b, // 2099
+		0x004e04ab, // 2100
+	}
+	solarTermTable = []uint64{
+		0x6aaaa6aa9a5a, // 1901
+		0xaaaaaabaaa6a, // 1902
+		0xaaabbabbafaa, // 1903
+		0x5aa665a65aab, // 1904
+		0x6aaaa6aa9a5a, // 1905
+		0xaaaaaaaaaa6a, // 1906
+		0xaaabbabbafaa, // 1907
+		0x5aa665a65aab, // 1908
+		0x6aaaa6aa9a5a, // 1909
+		0xaaaaaaaaaa6a, // 1910
+		0xaaabbabbafaa, // 1911
+		0x5aa665a65aab, // 1912
+		0x6aaaa6aa9a56, // 1913
+		0xaaaaaaaa9a5a, // 1914
+		0xaaabaabaaeaa, // 1915
+		0x569665a65aaa, // 1916
+		0x5aa6a6a69a56, // 1917
+		0x6aaaaaaa9a5a, // 1918
+		0xaaabaabaaeaa, // 1919
+		0x569665a65aaa, // 1920
+		0x5aa6a6a65a56, // 1921
+		0x6aaaaaaa9a5a, // 1922
+		0xaaabaabaaa6a, // 1923
+		0x569665a65aaa, // 1924
+		0x5aa6a6a65a56, // 1925
+		0x6aaaa6aa9a5a, // 1926
+		0xaaaaaabaaa6a, // 1927
+		0x555665665aaa, // 1928
+		0x5aa665a65a56, // 1929
+		0x6aaaa6aa9a5a, // 1930
+		0xaaaaaabaaa6a, // 1931
+		0x555665665aaa, // 1932
+		0x5aa665a65a56, // 1933
+		0x6aaaa6aa9a5a, // 1934
+		0xaaaaaaaaaa6a, // 1935
+		0x555665665aaa, // 1936
+		0x5aa665a65a56, // 1937
+		0x6aaaa6aa9a5a, // 1938
+		0xaaaaaaaaaa6a, // 1939
+		0x555665665aaa, // 1940
+		0x5aa665a65a56, // 1941
+		0x6aaaa6aa9a5a, // 1942
+		0xaaaaaaaaaa6a, // 1943
+		0x555665655aaa, // 1944
+		0x569665a65a56, // 1945
+		0x6aa6a6aa9a56, // 1946
+		0xaaaaaaaa9a5a, // 1947
+		0x5556556559aa, // 1948
+		0x569665a65a55, // 1949
+		0x6aa6a6a65a56, // 1950
+		0xaaaaaaaa9a5a, // 1951
+		0x5556556559aa, // 1952
+		0x569665a65a55, // 1953
+		0x5aa6a6a65a56, // 1954
+		0x6aaaa6aa9a5a, // 1955
+		0x5556556555aa, // 1956
+		0x569665a65a55, // 1957
+		0x5aa665a65a56, // 1958
+		0x6aaaa6aa9a5a, // 1959
+		0x55555565556a, // 1960
+		0x555665665a55, // 1961
+		0x5aa665a65a56, // 1962
+		0x6aaaa6aa9a5a, // 1963
+		0x55555565556a, // 1964
+		0x555665665a55, // 1965
+		0x5aa665a65a56, // 1966
+		0x6aaaa6aa9a5a, // 1967
+		0x55555555556a, // 1968
+		0x555665665a55, // 1969
+		0x5aa665a65a56, // 1970
+		0x6aaaa6aa9a5a, // 1971
+		0x55555555556a, // 1972
+		0x555665655a55, // 1973
+		0x5aa665a65a56, // 1974
+		0x6aa6a6aa9a5a, // 1975
+		0x55555555456a, // 1976
+		0x555655655a55, // 1977
+		0x5a9665a65a56, // 1978
+		0x6aa6a6a69a5a, // 1979
+		0x55555555456a, // 1980
+		0x555655655a55, // 1981
+		0x569665a65a56, // 1982
+		0x6aa6a6a65a56, // 1983
+		0x55555155455a, // 1984
+		0x555655655955, // 1985
+		0x569665a65a55, // 1986
+		0x5aa6a5a65a56, // 1987
+		0x15555155455a, // 1988
+		0x555555655555, // 1989
+		0x569665665a55, // 1990
+		0x5aa665a65a56, // 1991
+		0x15555155455a, // 1992
+		0x555555655515, // 1993
+		0x555665665a55, // 1994
+		0x5aa665a65a56, // 1995
+		0x15555155455a, // 1996
+		0x555555555515, // 1997
+		0x555665665a55, // 1998
+		0x5aa665a65a56, // 1999
+		0x15555155455a, // 2000
+		0x555555555515, // 2001
+		0x555665665a55, // 2002
+		0x5aa665a65a56, // 2003
+		0x15555155455a, // 2004
+		0x555555555515, // 2005
+		0x555655655a55, // 2006
+		0x5aa665a65a56, // 2007
+		0x15515155455a, // 2008
+		0x555555554515, // 2009
+		0x555655655a55, // 2010
+		0x5a9665a65a56, // 2011
+		0x15515151455a, // 2012
+		0x555551554515, // 2013
+		0x555655655a55, // 2014
+		0x569665a65a56, // 2015
+		0x155151510556, // 2016
+		0x555551554505, // 2017
+		0x555655655955, // 2018
+		0x569665665a55, // 2019
+		0x155110510556, // 2020
+		0x155551554505, // 2021
+		0x555555655555, // 2022
+		0x569665665a55, // 2023
+		0x055110510556, // 2024
+		0x155551554505, // 2025
+		0x555555555515, // 2026
+		0x555665665a55, // 2027
+		0x055110510556, // 2028
+		0x155551554505, // 2029
+		0x555555555515, // 2030
+		0x555665665a55, // 2031
+		0x055110510556, // 2032
+		0x155551554505, // 2033
+		0x555555555515, // 2034
+		0x555655655a55, // 2035
+		0x055110510556, // 2036
+		0x155551554505, // 2037
+		0x555555555515, // 2038
+		0x555655655a55, // 2039
+		0x055110510556, // 2040
+		0x155151514505, // 2041
+		0x555555554515, // 2042
+		0x555655655a55, // 2043
+		0x054110510556, // 2044
+		0x155151510505, // 2045
+		0x555551554515, // 2046
+		0x555655655a55, // 2047
+		0x014110110556, // 2048
+		0x155110510501, // 2049
+		0x555551554505, // 2050
+		0x555555655555, // 2051
+		0x014110110555, // 2052
+		0x155110510501, // 2053
+		0x555551554505, // 2054
+		0x555555555555, // 2055
+		0x014110110555, // 2056
+		0x055110510501, // 2057
+		0x155551554505, // 2058
+		0x555555555555, // 2059
+		0x000110110555, // 2060
+		0x055110510501, // 2061
+		0x155551554505, // 2062
+		0x555555555515, // 2063
+		0x000110110555, // 2064
+		0x055110510501, // 2065
+		0x155551554505, // 2066
+		0x555555555515, // 2067
+		0x000100100555, // 2068
+		0x055110510501, // 2069
+		0x155151514505, // 2070
+		0x555555555515, // 2071
+		0x000100100555, // 2072
+		0x054110510501, // 2073
+		0x155151514505, // 2074
+		0x555551554515, // 2075
+		0x000100100555, // 2076
+		0x054110510501, // 2077
+		0x155150510505, // 2078
+		0x555551554515, // 2079
+		0x000100100555, // 2080
+		0x014110110501, // 2081
+		0x155110510505, // 2082
+		0x555551554505, // 2083
+		0x000000100055, // 2084
+		0x014110110500, // 2085
+		0x155110510501, // 2086
+		0x555551554505, // 2087
+		0x000000000055, // 2088
+		0x014110110500, // 2089
+		0x055110510501, // 2090
+		0x155551554505, // 2091
+		0x000000000055, // 2092
+		0x000110110500, // 2093
+		0x055110510501, // 2094
+		0x155551554505, // 2095
+		0x000000000015, // 2096
+		0x000100110500, // 2097
+		0x055110510501, // 2098
+		0x155551554505, // 2099
+		0x555555555515, // 2100
+	}
+	solarTermBase = []uint8{4, 19, 3, 18, 4, 19, 4, 19, 4, 20, 4, 20, 6, 22, 6, 22, 6, 22, 7, 22, 6, 21, 6, 21}
+}
@@ -0,0 +1,81 @@
+package lunar
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	from, to := NewDate(1901, 1, 1), NewDate(2100, 12, 31)
+	expected, err := NewWithSource(NewHKOSource(os.DirFS("files"))).Range(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := New()
+	actual, err := h.Range(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("Table error, expected: %d days, actual: %d days", len(expected), len(actual))
+	}
+
+	for i, e := range expected {
+		if a := actual[i]; *a != *e {
+			t.Errorf("Table error, expected: %+v, actual: %+v", e, a)
+		}
+		for _, dt := range []DateType{e.Date, e.LunarDate} {
+			a, err := h.Calendar(dt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *a != *e {
+				t.Errorf("Table error, expected: %+v, actual: %+v", e, a)
+			}
+		}
+	}
+
+	for _, dt := range []DateType{NewDate(1900, 12, 31), NewDate(2101, 1, 1), NewLunarDate(NewDate(2100, 12, 2), false)} {
+		if _, err := h.Calendar(dt); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Table error, expected: %v, actual: %v", fs.ErrNotExist, err)
+		}
+	}
+	for _, dt := range []DateType{NewDate(2021, 2, 29), NewLunarDate(NewDate(2021, 4, 1), true), NewLunarDate(NewDate(2021, 1, 30), false)} {
+		if _, err := h.Calendar(dt); err != ErrNotFound {
+			t.Errorf("Table error, expected: %v, actual: %v", ErrNotFound, err)
+		}
+	}
+}
+
+func TestTableSolarTerms(t *testing.T) {
+	hko, h := NewWithSource(NewHKOSource(os.DirFS("files"))), New()
+	for year := 1901; year < 2100; year++ {
+		expected, err := hko.GetSolarTerms(year)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := h.GetSolarTerms(year)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(actual) != len(expected) {
+			t.Fatalf("TableSolarTerms error, year: %d, expected: %d terms, actual: %d terms", year, len(expected), len(actual))
+		}
+		for i, e := range expected {
+			if a := actual[i]; *a != *e {
+				t.Errorf("TableSolarTerms error, expected: %+v, actual: %+v", e, a)
+			}
+		}
+	}
+
+	// the table is read directly, no years are built
+	if _, err := h.Range(NewDate(2000, 1, 1), NewDate(2001, 12, 31)); err != nil {
+		t.Fatal(err)
+	}
+	if actual := h.Stats(); actual.Misses != 0 || len(actual.LoadedYears) != 0 {
+		t.Errorf("TableSolarTerms error, expected: no loaded years, actual: %+v", actual)
+	}
+}
@@ -0,0 +1,130 @@
+package lunar
+
+// Zodiac chinese zodiac animal (生肖)
+type Zodiac int
+
+const (
+	// ZodiacRat 鼠
+	ZodiacRat Zodiac = iota
+	// ZodiacOx 牛
+	ZodiacOx
+	// ZodiacTiger 虎
+	ZodiacTiger
+	// ZodiacRabbit 兔
+	ZodiacRabbit
+	// ZodiacDragon 龙
+	ZodiacDragon
+	// ZodiacSnake 蛇
+	ZodiacSnake
+	// ZodiacHorse 马
+	ZodiacHorse
+	// ZodiacGoat 羊
+	ZodiacGoat
+	// ZodiacMonkey 猴
+	ZodiacMonkey
+	// ZodiacRooster 鸡
+	ZodiacRooster
+	// ZodiacDog 狗
+	ZodiacDog
+	// ZodiacPig 猪
+	ZodiacPig
+)
+
+var zodiacNames = []string{"鼠", "牛", "虎", "兔", "龙", "蛇", "马", "羊", "猴", "鸡", "狗", "猪"}
+
+func (z Zodiac) String() string {
+	if z < ZodiacRat || z > ZodiacPig {
+		return ""
+	}
+	return zodiacNames[z]
+}
+
+// Element five elements (五行)
+type Element int
+
+const (
+	// ElementWood 木
+	ElementWood Element = iota
+	// ElementFire 火
+	ElementFire
+	// ElementEarth 土
+	ElementEarth
+	// ElementMetal 金
+	ElementMetal
+	// ElementWater 水
+	ElementWater
+)
+
+var elementNames = []string{"木", "火", "土", "金", "水"}
+
+func (e Element) String() string {
+	if e < ElementWood || e > ElementWater {
+		return ""
+	}
+	return elementNames[e]
+}
+
+// Element returns the element of the stem, eg. 甲乙 is 木
+func (s Stem) Element() Element {
+	return Element(s / 2)
+}
+
+// Zodiac returns the zodiac animal of the branch
+func (b Branch) Zodiac() Zodiac {
+	return Zodiac(b)
+}
+
+// YearBoundary defines when a new zodiac year begins
+type YearBoundary int
+
+const (
+	// BoundaryLunarNewYear zodiac year begins at 正月初一
+	BoundaryLunarNewYear YearBoundary = iota
+	// BoundaryLiChun zodiac year begins at 立春
+	BoundaryLiChun
+)
+
+// ZodiacInfo zodiac info of a year
+type ZodiacInfo struct {
+	Year    Sexagenary
+	Zodiac  Zodiac
+	Element Element
+}
+
+func newZodiacInfo(year Sexagenary) *ZodiacInfo {
+	return &ZodiacInfo{
+		Year:    year,
+		Zodiac:  year.Branch.Zodiac(),
+		Element: year.Stem.Element(),
+	}
+}
+
+// GetZodiac query zodiac info of the year the date belongs to
+func GetZodiac(dt DateType, boundary YearBoundary) (*ZodiacInfo, error) {
+	return defaultHandler.GetZodiac(dt, boundary)
+}
+
+// GetZodiac query zodiac info of the year the date belongs to
+func (h *Handler) GetZodiac(dt DateType, boundary YearBoundary) (*ZodiacInfo, error) {
+	r, err := h.Calendar(dt)
+	if err != nil {
+		return nil, err
+	}
+
+	if boundary == BoundaryLiChun {
+		return newZodiacInfo(yearPillar(solarYear(r))), nil
+	}
+
+	return newZodiacInfo(r.YearPillar), nil
+}
+
+// solarYear returns the year which begins at 立春,
+// days of January and February before 立春 are in the 子 or 丑 month of the previous one
+func solarYear(r *Result) int {
+	b := r.MonthPillar.Branch
+	if r.Date.Month <= 2 && (b == BranchZi || b == BranchChou) {
+		return r.Date.Year - 1
+	}
+
+	return r.Date.Year
+}